@@ -0,0 +1,83 @@
+package aoc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseError describes a problem found while parsing puzzle input. Line and Column are 1-based and
+// left at zero when unknown; parsers working on a single line fill in the column and token while the
+// code scanning the lines adds the line number with AtLine, and the file with InFile.
+type ParseError struct {
+	File   string
+	Line   int
+	Column int
+	Token  string
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	var loc []string
+	if e.File != "" {
+		loc = append(loc, e.File)
+	}
+	if e.Line > 0 {
+		loc = append(loc, fmt.Sprintf("line %d", e.Line))
+	}
+	if e.Column > 0 {
+		loc = append(loc, fmt.Sprintf("column %d", e.Column))
+	}
+
+	if len(loc) == 0 {
+		return e.Err.Error()
+	}
+
+	return fmt.Sprintf("%s: %v", strings.Join(loc, ", "), e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// AtLine sets the line of every ParseError within err that does not have one yet and returns err.
+func AtLine(err error, line int) error {
+	eachParseError(err, func(pe *ParseError) {
+		if pe.Line == 0 {
+			pe.Line = line
+		}
+	})
+
+	return err
+}
+
+// InFile sets the file of every ParseError within err that does not have one yet and returns err.
+func InFile(err error, file string) error {
+	eachParseError(err, func(pe *ParseError) {
+		if pe.File == "" {
+			pe.File = file
+		}
+	})
+
+	return err
+}
+
+// eachParseError calls fn for every ParseError found while unwrapping err, following both single
+// wrapped errors and those joined with errors.Join.
+func eachParseError(err error, fn func(*ParseError)) {
+	if err == nil {
+		return
+	}
+
+	if pe, ok := err.(*ParseError); ok {
+		fn(pe)
+	}
+
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, inner := range e.Unwrap() {
+			eachParseError(inner, fn)
+		}
+	case interface{ Unwrap() error }:
+		eachParseError(e.Unwrap(), fn)
+	}
+}
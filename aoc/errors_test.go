@@ -0,0 +1,55 @@
+package aoc
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      *ParseError
+		expected string
+	}{
+		{
+			name:     "no location",
+			err:      &ParseError{Err: errors.New("bad")},
+			expected: "bad",
+		},
+		{
+			name:     "column only",
+			err:      &ParseError{Column: 4, Token: "x", Err: errors.New("bad")},
+			expected: "column 4: bad",
+		},
+		{
+			name:     "full location",
+			err:      &ParseError{File: "input.txt", Line: 2, Column: 4, Token: "x", Err: errors.New("bad")},
+			expected: "input.txt, line 2, column 4: bad",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.err.Error())
+		})
+	}
+}
+
+func TestAtLineInFile(t *testing.T) {
+	inner := errors.New("inner")
+	first := &ParseError{Column: 1, Err: inner}
+	second := &ParseError{Line: 7, Column: 2, Err: errors.New("other")}
+
+	err := errors.Join(first, fmt.Errorf("wrapped: %w", second), errors.New("plain"))
+
+	err = InFile(AtLine(err, 3), "input.txt")
+
+	assert.Equal(t, &ParseError{File: "input.txt", Line: 3, Column: 1, Err: inner}, first)
+	assert.Equal(t, "input.txt", second.File)
+	assert.Equal(t, 7, second.Line, "existing lines are kept")
+	assert.ErrorIs(t, err, inner)
+	assert.Nil(t, AtLine(nil, 1))
+}
@@ -0,0 +1,37 @@
+package aoc
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// MaxLineSize is the longest line, in bytes, ScanLines will read. Only the current line is held in
+// memory, so this also bounds the memory used to read an input.
+var MaxLineSize = 64 << 20
+
+// ScanLines streams r one line at a time, calling fn with each line and its 1-based number. Line
+// endings are not included in the text passed to fn and a final line without one is still read.
+// Problems reading r, including lines longer than MaxLineSize, are returned as a ParseError located
+// at the line that could not be read.
+func ScanLines(r io.Reader, fn func(line int, text string)) error {
+	size := bufio.MaxScanTokenSize
+	if MaxLineSize < size {
+		size = MaxLineSize
+	}
+
+	inputScanner := bufio.NewScanner(r)
+	inputScanner.Buffer(make([]byte, 0, size), MaxLineSize)
+
+	var line int
+	for inputScanner.Scan() {
+		line++
+		fn(line, inputScanner.Text())
+	}
+
+	if err := inputScanner.Err(); err != nil {
+		return &ParseError{Line: line + 1, Err: fmt.Errorf("reading line: %w", err)}
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package aoc
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanLines(t *testing.T) {
+	long := strings.Repeat("x", 3*bufio.MaxScanTokenSize)
+
+	testCases := []struct {
+		name        string
+		input       io.Reader
+		maxLineSize int
+		expected    []string
+		expectedErr error
+	}{
+		{
+			name:  "empty",
+			input: strings.NewReader(""),
+		},
+		{
+			name:     "no trailing newline",
+			input:    strings.NewReader("a\r\nb\n\nc"),
+			expected: []string{"1:a", "2:b", "3:", "4:c"},
+		},
+		{
+			name:     "lines longer than the default scanner buffer",
+			input:    strings.NewReader("a\n" + long + "\nb\n"),
+			expected: []string{"1:a", "2:" + long, "3:b"},
+		},
+		{
+			name:        "line longer than the maximum",
+			input:       strings.NewReader("a\nbbbbbbbbbb\nc\n"),
+			maxLineSize: 8,
+			expected:    []string{"1:a"},
+			expectedErr: &ParseError{Line: 2, Err: errors.New("reading line: bufio.Scanner: token too long")},
+		},
+		{
+			name:        "reader failure",
+			input:       io.MultiReader(strings.NewReader("a\nb\n"), iotest.ErrReader(errors.New("disk on fire"))),
+			expected:    []string{"1:a", "2:b"},
+			expectedErr: &ParseError{Line: 3, Err: errors.New("reading line: disk on fire")},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.maxLineSize > 0 {
+				defer func(max int) { MaxLineSize = max }(MaxLineSize)
+				MaxLineSize = tc.maxLineSize
+			}
+
+			var actual []string
+			err := ScanLines(tc.input, func(line int, text string) {
+				actual = append(actual, fmt.Sprintf("%d:%s", line, text))
+			})
+
+			assert.Equal(t, tc.expected, actual)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
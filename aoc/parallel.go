@@ -0,0 +1,102 @@
+package aoc
+
+import (
+	"io"
+	"sync"
+)
+
+// Parallel is implemented by solvers able to spread the lines of their input across several
+// goroutines.
+type Parallel interface {
+	SetWorkers(n int)
+}
+
+const (
+	// batchLines and batchBytes bound how much input is handed to a worker at once, whichever is
+	// reached first.
+	batchLines = 256
+	batchBytes = 1 << 20
+)
+
+// batch is a run of consecutive lines evaluated together by a single worker.
+type batch[T any] struct {
+	first   int
+	lines   []string
+	results []T
+	done    chan struct{}
+}
+
+// MapLines streams the lines of r through fn, handing every result to reduce in the order the lines
+// appear in r. Up to workers goroutines run fn at once while reduce is only ever called from one at a
+// time, so it needs no locking of its own. With fewer than two workers every line is handled in turn
+// on the calling goroutine. A line that cannot be read is reported as with ScanLines, once every line
+// before it has been reduced.
+func MapLines[T any](r io.Reader, workers int, fn func(line int, text string) T, reduce func(line int, v T)) error {
+	if workers < 2 {
+		return ScanLines(r, func(line int, text string) {
+			reduce(line, fn(line, text))
+		})
+	}
+
+	jobs := make(chan *batch[T])
+	ordered := make(chan *batch[T], workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for b := range jobs {
+				b.results = make([]T, len(b.lines))
+				for i, text := range b.lines {
+					b.results[i] = fn(b.first+i, text)
+				}
+				close(b.done)
+			}
+		}()
+	}
+
+	reduced := make(chan struct{})
+	go func() {
+		defer close(reduced)
+		for b := range ordered {
+			<-b.done
+			for i, v := range b.results {
+				reduce(b.first+i, v)
+			}
+		}
+	}()
+
+	// batches join the queue of results before being handed out so the reducer always waits on
+	// them in input order
+	var next *batch[T]
+	var size int
+	flush := func() {
+		if next == nil {
+			return
+		}
+		ordered <- next
+		jobs <- next
+		next, size = nil, 0
+	}
+
+	err := ScanLines(r, func(line int, text string) {
+		if next == nil {
+			next = &batch[T]{first: line, done: make(chan struct{})}
+		}
+		next.lines = append(next.lines, text)
+		size += len(text)
+
+		if len(next.lines) == batchLines || size >= batchBytes {
+			flush()
+		}
+	})
+	flush()
+
+	close(jobs)
+	close(ordered)
+	wg.Wait()
+	<-reduced
+
+	return err
+}
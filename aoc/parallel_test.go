@@ -0,0 +1,72 @@
+package aoc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapLines(t *testing.T) {
+	lines := make([]string, 3*batchLines+7)
+	for i := range lines {
+		lines[i] = fmt.Sprint(i)
+	}
+	input := strings.Join(lines, "\n")
+
+	var expected []string
+	for i, l := range lines {
+		expected = append(expected, fmt.Sprintf("%d:%s!", i+1, l))
+	}
+
+	testCases := []struct {
+		name        string
+		input       func() io.Reader
+		expected    []string
+		expectedErr error
+	}{
+		{
+			name:  "empty",
+			input: func() io.Reader { return strings.NewReader("") },
+		},
+		{
+			name:     "results reduced in input order",
+			input:    func() io.Reader { return strings.NewReader(input) },
+			expected: expected,
+		},
+		{
+			name: "reader failure after every line read",
+			input: func() io.Reader {
+				return io.MultiReader(strings.NewReader("a\nb\n"), iotest.ErrReader(errors.New("disk on fire")))
+			},
+			expected:    []string{"1:a!", "2:b!"},
+			expectedErr: errors.New("line 3: reading line: disk on fire"),
+		},
+	}
+
+	for _, tc := range testCases {
+		for _, workers := range []int{0, 1, 2, 8} {
+			t.Run(fmt.Sprintf("%s with %d workers", tc.name, workers), func(t *testing.T) {
+				var actual []string
+				err := MapLines(tc.input(), workers, func(line int, text string) string {
+					return fmt.Sprintf("%d:%s!", line, text)
+				}, func(line int, v string) {
+					actual = append(actual, v)
+				})
+
+				assert.Equal(t, tc.expected, actual)
+				if tc.expectedErr != nil {
+					require.Error(t, err)
+					assert.Equal(t, tc.expectedErr.Error(), err.Error())
+					return
+				}
+				require.NoError(t, err)
+			})
+		}
+	}
+}
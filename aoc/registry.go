@@ -0,0 +1,121 @@
+// Package aoc holds the pieces shared by every day's solution, most notably the registry used by
+// the aoc command to find the solver for a given day.
+package aoc
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// Answer is the result of solving one part of a puzzle.
+type Answer int
+
+// Solver solves both parts of a single day's puzzle. Each part reads the full puzzle input from r.
+type Solver interface {
+	Part1(r io.Reader) (Answer, error)
+	Part2(r io.Reader) (Answer, error)
+}
+
+var (
+	mu       sync.RWMutex
+	registry = map[int]Solver{}
+)
+
+// Register makes a solver available for the given day. It is intended to be called from the init
+// function of each day's package and panics if the day is already taken.
+func Register(day int, s Solver) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if s == nil {
+		panic(fmt.Sprintf("aoc: nil solver registered for day %d", day))
+	}
+
+	if _, ok := registry[day]; ok {
+		panic(fmt.Sprintf("aoc: solver for day %d registered twice", day))
+	}
+
+	registry[day] = s
+}
+
+// Lookup returns the solver registered for the given day.
+func Lookup(day int) (Solver, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	s, ok := registry[day]
+	if !ok {
+		return nil, fmt.Errorf("no solver registered for day %d", day)
+	}
+
+	return s, nil
+}
+
+// Days returns every day with a registered solver in ascending order.
+func Days() []int {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	days := make([]int, 0, len(registry))
+	for d := range registry {
+		days = append(days, d)
+	}
+	sort.Ints(days)
+
+	return days
+}
+
+// Solve runs the requested part of s against the input read from r.
+func Solve(s Solver, part int, r io.Reader) (Answer, error) {
+	switch part {
+	case 1:
+		return s.Part1(r)
+	case 2:
+		return s.Part2(r)
+	default:
+		return 0, fmt.Errorf("invalid part %d, expected 1 or 2", part)
+	}
+}
+
+// Configurer is implemented by solvers that expose options of their own on the command line. The
+// same solver serves every command line parsed by the process, so Flags puts every option back to
+// its default before adding it to fs.
+type Configurer interface {
+	Flags(fs *flag.FlagSet)
+}
+
+// RegisterFlags adds the options of every registered solver implementing Configurer to fs, in day
+// order.
+func RegisterFlags(fs *flag.FlagSet) {
+	for _, d := range Days() {
+		mu.RLock()
+		s := registry[d]
+		mu.RUnlock()
+
+		if c, ok := s.(Configurer); ok {
+			c.Flags(fs)
+		}
+	}
+}
+
+// Warner is implemented by solvers that tolerate questionable input, reporting what was tolerated
+// while solving the most recent input.
+type Warner interface {
+	Warnings() []error
+}
+
+// Reporter is implemented by solvers able to describe the most recent input they solved in more
+// detail than their answers, when asked to on the command line.
+type Reporter interface {
+	Report(w io.Writer) error
+}
+
+// Explainer is implemented by solvers able to describe how they reach their answers as they solve,
+// when asked to on the command line. ExplainTo is called before solving with where the description
+// should go.
+type Explainer interface {
+	ExplainTo(w io.Writer)
+}
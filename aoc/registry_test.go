@@ -0,0 +1,106 @@
+package aoc
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lenSolver answers part 1 with the length of the input and part 2 with twice that.
+type lenSolver struct{}
+
+func (lenSolver) Part1(r io.Reader) (Answer, error) {
+	b, err := io.ReadAll(r)
+	return Answer(len(b)), err
+}
+
+func (lenSolver) Part2(r io.Reader) (Answer, error) {
+	b, err := io.ReadAll(r)
+	return Answer(2 * len(b)), err
+}
+
+func TestRegister(t *testing.T) {
+	Register(101, lenSolver{})
+
+	assert.Panics(t, func() { Register(101, lenSolver{}) }, "duplicate registration")
+	assert.Panics(t, func() { Register(102, nil) }, "nil solver")
+	assert.Contains(t, Days(), 101)
+	assert.NotContains(t, Days(), 102)
+}
+
+func TestLookup(t *testing.T) {
+	Register(103, lenSolver{})
+
+	s, err := Lookup(103)
+	require.NoError(t, err)
+	assert.Equal(t, lenSolver{}, s)
+
+	_, err = Lookup(104)
+	require.Error(t, err)
+	assert.Equal(t, "no solver registered for day 104", err.Error())
+}
+
+// flagSolver is a lenSolver exposing a single flag.
+type flagSolver struct {
+	lenSolver
+	verbose bool
+}
+
+func (s *flagSolver) Flags(fs *flag.FlagSet) {
+	fs.BoolVar(&s.verbose, "verbose-105", false, "test flag")
+}
+
+func TestRegisterFlags(t *testing.T) {
+	s := &flagSolver{}
+	Register(105, s)
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	RegisterFlags(fs)
+
+	require.NoError(t, fs.Parse([]string{"-verbose-105"}))
+	assert.True(t, s.verbose)
+}
+
+func TestSolve(t *testing.T) {
+	testCases := []struct {
+		name        string
+		part        int
+		expected    Answer
+		expectedErr error
+	}{
+		{
+			name:     "part 1",
+			part:     1,
+			expected: 3,
+		},
+		{
+			name:     "part 2",
+			part:     2,
+			expected: 6,
+		},
+		{
+			name:        "unknown part",
+			part:        3,
+			expectedErr: fmt.Errorf("invalid part 3, expected 1 or 2"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := Solve(lenSolver{}, tc.part, strings.NewReader("abc"))
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
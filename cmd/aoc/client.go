@@ -0,0 +1,132 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// year is the Advent of Code event the puzzles of this repository belong to.
+	year = 2023
+	// defaultBaseURL is where the puzzles are published.
+	defaultBaseURL = "https://adventofcode.com"
+	// sessionEnv names the environment variable holding the session token, which takes precedence
+	// over the session file.
+	sessionEnv = "AOC_SESSION"
+	// userAgent identifies the client to the site, as its maintainers ask automated tools to do.
+	userAgent = "github.com/mxygem/advent-of-code-2023/cmd/aoc (Go net/http)"
+)
+
+// clientConfig holds the command line options shared by every command talking to the site.
+type clientConfig struct {
+	baseURL     string
+	sessionFile string
+}
+
+// defaultSessionFile returns where the session token is read from when it is not in the
+// environment, or an empty string if there is no user configuration directory.
+func defaultSessionFile() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "aoc", "session")
+}
+
+// clientFlags adds the options shared by every command talking to the site to fs.
+func clientFlags(fs *flag.FlagSet) *clientConfig {
+	var cfg clientConfig
+	fs.StringVar(&cfg.baseURL, "base-url", defaultBaseURL, "address of the Advent of Code site")
+	fs.StringVar(&cfg.sessionFile, "session-file", defaultSessionFile(), fmt.Sprintf("location of a `file` holding the session token, used when %s is not set", sessionEnv))
+
+	return &cfg
+}
+
+// session returns the session token from the environment or else the session file.
+func (cfg *clientConfig) session() (string, error) {
+	if token := strings.TrimSpace(os.Getenv(sessionEnv)); token != "" {
+		return token, nil
+	}
+
+	missing := fmt.Errorf("no session token, set %s or save it to the -session-file", sessionEnv)
+	if cfg.sessionFile == "" {
+		return "", missing
+	}
+
+	b, err := os.ReadFile(cfg.sessionFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", missing
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading session token: %w", err)
+	}
+
+	token := strings.TrimSpace(string(b))
+	if token == "" {
+		return "", fmt.Errorf("session file %s is empty", cfg.sessionFile)
+	}
+
+	return token, nil
+}
+
+// client talks to the Advent of Code site on behalf of the owner of a session token.
+type client struct {
+	baseURL string
+	session string
+	http    *http.Client
+}
+
+// newClient returns a client for the site described by cfg.
+func (cfg *clientConfig) newClient() (*client, error) {
+	token, err := cfg.session()
+	if err != nil {
+		return nil, err
+	}
+
+	return &client{
+		baseURL: strings.TrimSuffix(cfg.baseURL, "/"),
+		session: token,
+		http:    &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// do sends req with the session token and user agent, returning the body of a successful response.
+func (c *client) do(req *http.Request) ([]byte, error) {
+	req.Header.Set("User-Agent", userAgent)
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.session})
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s %s: %s: %s", req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return body, nil
+}
+
+// input downloads the puzzle input of the given day.
+func (c *client) input(day int) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/%d/day/%d/input", c.baseURL, year, day), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.do(req)
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// fetchCmd downloads the puzzle input of the requested day to where runCmd looks for it by default.
+// Inputs already there are never downloaded again.
+func fetchCmd(args []string, out, errOut io.Writer) error {
+	fs := flag.NewFlagSet("fetch", flag.ContinueOnError)
+	fs.SetOutput(errOut)
+
+	var day int
+	var inputLoc string
+
+	fs.IntVar(&day, "day", 0, "day of the puzzle to download the input of")
+	fs.StringVar(&inputLoc, "input", "", "location to save the input to, defaults to the day's puzzle_input.txt")
+	cfg := clientFlags(fs)
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	if day < 1 || day > 25 {
+		return fmt.Errorf("invalid day %d, expected 1 to 25", day)
+	}
+
+	if inputLoc == "" {
+		inputLoc = defaultInput(day)
+	}
+
+	if _, err := os.Stat(inputLoc); err == nil {
+		fmt.Fprintf(out, "day %d input already saved to %s\n", day, inputLoc)
+		return nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("checking for saved input: %w", err)
+	}
+
+	c, err := cfg.newClient()
+	if err != nil {
+		return err
+	}
+
+	input, err := c.input(day)
+	if err != nil {
+		return fmt.Errorf("downloading day %d input: %w", day, err)
+	}
+
+	if err := saveInput(inputLoc, input); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "day %d input saved to %s\n", day, inputLoc)
+
+	return nil
+}
+
+// saveInput writes input to loc, creating its directory if need be. The input is written to a
+// temporary file first so an interrupted save never leaves a partial input behind.
+func saveInput(loc string, input []byte) error {
+	dir := filepath.Dir(loc)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating input directory: %w", err)
+	}
+
+	f, err := os.CreateTemp(dir, ".input-*")
+	if err != nil {
+		return fmt.Errorf("saving input: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(input); err != nil {
+		f.Close()
+		return fmt.Errorf("saving input: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("saving input: %w", err)
+	}
+
+	if err := os.Rename(f.Name(), loc); err != nil {
+		return fmt.Errorf("saving input: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubSite stands in for the Advent of Code site, answering every request with handler and counting
+// the requests it receives.
+type stubSite struct {
+	*httptest.Server
+	requests int
+}
+
+func newStubSite(t *testing.T, handler http.HandlerFunc) *stubSite {
+	t.Helper()
+
+	site := &stubSite{}
+	site.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		site.requests++
+		handler(w, r)
+	}))
+	t.Cleanup(site.Close)
+
+	return site
+}
+
+func TestFetch(t *testing.T) {
+	site := newStubSite(t, func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie("session")
+		switch {
+		case err != nil || cookie.Value != "token":
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+		case r.URL.Path != "/2023/day/3/input":
+			http.NotFound(w, r)
+		case r.UserAgent() != userAgent:
+			http.Error(w, "unexpected user agent "+r.UserAgent(), http.StatusForbidden)
+		default:
+			fmt.Fprint(w, "467..114..\n...*......\n")
+		}
+	})
+
+	dir := t.TempDir()
+	sessionFile := filepath.Join(dir, "session")
+	require.NoError(t, os.WriteFile(sessionFile, []byte("token\n"), 0o600))
+
+	testCases := []struct {
+		name             string
+		env              string
+		args             []string
+		existing         string
+		expected         string
+		expectedInput    string
+		expectedRequests int
+		expectedErr      error
+	}{
+		{
+			name:             "session from the environment",
+			env:              "token",
+			args:             []string{"-day", "3"},
+			expected:         "day 3 input saved to %s\n",
+			expectedInput:    "467..114..\n...*......\n",
+			expectedRequests: 1,
+		},
+		{
+			name:             "session from the file",
+			args:             []string{"-day", "3", "-session-file", sessionFile, "-base-url", site.URL + "/"},
+			expected:         "day 3 input saved to %s\n",
+			expectedInput:    "467..114..\n...*......\n",
+			expectedRequests: 1,
+		},
+		{
+			name:          "saved inputs are kept",
+			args:          []string{"-day", "3"},
+			existing:      "mine",
+			expected:      "day 3 input already saved to %s\n",
+			expectedInput: "mine",
+		},
+		{
+			name:        "no session",
+			args:        []string{"-day", "3", "-session-file", filepath.Join(dir, "missing")},
+			expectedErr: fmt.Errorf("no session token, set AOC_SESSION or save it to the -session-file"),
+		},
+		{
+			name:             "wrong session",
+			env:              "stale",
+			args:             []string{"-day", "3"},
+			expectedRequests: 1,
+			expectedErr:      fmt.Errorf("downloading day 3 input: GET /2023/day/3/input: 400 Bad Request: Puzzle inputs differ by user.  Please log in to get your puzzle input."),
+		},
+		{
+			name:             "not yet published",
+			env:              "token",
+			args:             []string{"-day", "4"},
+			expectedRequests: 1,
+			expectedErr:      fmt.Errorf("downloading day 4 input: GET /2023/day/4/input: 404 Not Found: 404 page not found"),
+		},
+		{
+			name:        "no day",
+			env:         "token",
+			expectedErr: fmt.Errorf("invalid day 0, expected 1 to 25"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(sessionEnv, tc.env)
+			site.requests = 0
+
+			loc := filepath.Join(t.TempDir(), "day-03", "puzzle_input.txt")
+			if tc.existing != "" {
+				require.NoError(t, os.MkdirAll(filepath.Dir(loc), 0o755))
+				require.NoError(t, os.WriteFile(loc, []byte(tc.existing), 0o600))
+			}
+
+			args := append([]string{"fetch", "-base-url", site.URL, "-session-file", "", "-input", loc}, tc.args...)
+			var out, errOut bytes.Buffer
+			err := run(args, &out, &errOut)
+			assert.Equal(t, tc.expectedRequests, site.requests)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				assert.NoFileExists(t, loc)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, fmt.Sprintf(tc.expected, loc), out.String())
+
+			saved, err := os.ReadFile(loc)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedInput, string(saved))
+
+			entries, err := os.ReadDir(filepath.Dir(loc))
+			require.NoError(t, err)
+			assert.Len(t, entries, 1, "no temporary files are left behind")
+		})
+	}
+}
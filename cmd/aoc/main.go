@@ -0,0 +1,56 @@
+// Command aoc runs the Advent of Code 2023 solutions found in this repository.
+//
+// Usage:
+//
+//	aoc run -day 3 -part 2 -input day-03/puzzle_input.txt
+//	aoc fetch -day 3
+//	aoc submit -day 3 -part 2
+//	aoc verify
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	_ "github.com/mxygem/advent-of-code-2023/day-01"
+	_ "github.com/mxygem/advent-of-code-2023/day-02"
+	_ "github.com/mxygem/advent-of-code-2023/day-03"
+)
+
+const usage = `usage: aoc <command> [flags]
+
+commands:
+  run     solve a day's puzzle
+  fetch   download a day's puzzle input
+  submit  submit the answer to a day's puzzle
+  verify  check every day's answers against the known ones`
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		log.Fatalf("%s", err)
+	}
+}
+
+func run(args []string, out, errOut io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no command given\n%s", usage)
+	}
+
+	switch args[0] {
+	case "run":
+		return runCmd(args[1:], out, errOut)
+	case "fetch":
+		return fetchCmd(args[1:], out, errOut)
+	case "submit":
+		return submitCmd(args[1:], out, errOut)
+	case "verify":
+		return verifyCmd(args[1:], out, errOut)
+	case "help", "-h", "-help", "--help":
+		fmt.Fprintln(out, usage)
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q\n%s", args[0], usage)
+	}
+}
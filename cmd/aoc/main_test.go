@@ -0,0 +1,268 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	schematic := filepath.Join(dir, "day3.txt")
+	require.NoError(t, os.WriteFile(schematic, []byte("467..114..\n...*......\n..35..633.\n"), 0o600))
+	games := filepath.Join(dir, "day2.txt")
+	require.NoError(t, os.WriteFile(games, []byte("Game 1: 3 blue, 4 red\nGame 2: 1 blue, 2 yellow\n"), 0o600))
+	calibration := filepath.Join(dir, "day1.txt")
+	require.NoError(t, os.WriteFile(calibration, []byte("x1y\n"), 0o600))
+
+	testCases := []struct {
+		name             string
+		args             []string
+		expected         string
+		expectedWarnings string
+		expectedErr      error
+	}{
+		{
+			name:        "no command",
+			expectedErr: fmt.Errorf("no command given\n%s", usage),
+		},
+		{
+			name:        "unknown command",
+			args:        []string{"fly"},
+			expectedErr: fmt.Errorf("unknown command %q\n%s", "fly", usage),
+		},
+		{
+			name:        "no day",
+			args:        []string{"run", "-input", schematic},
+			expectedErr: fmt.Errorf("no day given"),
+		},
+		{
+			name:        "unregistered day",
+			args:        []string{"run", "-day", "25", "-input", schematic},
+			expectedErr: fmt.Errorf("no solver registered for day 25"),
+		},
+		{
+			name:     "single part",
+			args:     []string{"run", "-day", "3", "-part", "2", "-input", schematic},
+			expected: "day 3 part 2: 16345\n",
+		},
+		{
+			name:     "both parts",
+			args:     []string{"run", "-day", "3", "-input", schematic},
+			expected: "day 3 part 1: 502\nday 3 part 2: 16345\n",
+		},
+		{
+			name: "report follows the answers",
+			args: []string{"run", "-day", "3", "-report", "-input", schematic},
+			expected: `day 3 part 1: 502
+day 3 part 2: 16345
+symbol       operator  parts  evaluated  skipped  total
+*            product   2      1          0        16345
+grand total                                       16345
+`,
+		},
+		{
+			name: "rendering follows the answers",
+			args: []string{"run", "-day", "3", "-part", "1", "-render", "mono", "-input", schematic},
+			expected: `day 3 part 1: 502
+[467]..(114)..
+...{*}......
+..[35]..(633).
+`,
+		},
+		{
+			name: "explanations come before their answers",
+			args: []string{"run", "-day", "1", "-explain", "-input", calibration},
+			expected: `line 1 "x1y": value 11, total 11
+  bytes [1,2) digit   "1" = 1, first, last
+day 1 part 1: 11
+line 1 "x1y": value 11, total 11
+  bytes [1,2) digit   "1" = 1, first, last
+day 1 part 2: 11
+`,
+		},
+		{
+			name:             "warnings are printed once",
+			args:             []string{"run", "-day", "2", "-input", games},
+			expected:         "day 2 part 1: 3\nday 2 part 2: 13\n",
+			expectedWarnings: fmt.Sprintf("warning: %s, line 2, column 17: unknown color \"yellow\"\n", games),
+		},
+		{
+			name:             "workers give the same answers and warnings",
+			args:             []string{"run", "-day", "2", "-workers", "4", "-input", games},
+			expected:         "day 2 part 1: 3\nday 2 part 2: 13\n",
+			expectedWarnings: fmt.Sprintf("warning: %s, line 2, column 17: unknown color \"yellow\"\n", games),
+		},
+		{
+			name:        "workers on a day without support",
+			args:        []string{"run", "-day", "3", "-workers", "4", "-input", schematic},
+			expectedErr: fmt.Errorf("day 3 does not support -workers"),
+		},
+		{
+			name:        "no workers",
+			args:        []string{"run", "-day", "2", "-workers", "0", "-input", games},
+			expectedErr: fmt.Errorf("invalid number of workers 0, expected at least 1"),
+		},
+		{
+			name:        "unknown format",
+			args:        []string{"run", "-day", "3", "-format", "yaml", "-input", schematic},
+			expectedErr: fmt.Errorf(`unknown format "yaml", expected text or json`),
+		},
+		{
+			name:        "strict mode rejects warnings",
+			args:        []string{"run", "-day", "2", "-strict", "-input", games},
+			expectedErr: fmt.Errorf("solving day 2 part 1: %s, line 2, column 17: unknown color \"yellow\"", games),
+		},
+		{
+			name:        "unknown part",
+			args:        []string{"run", "-day", "3", "-part", "3", "-input", schematic},
+			expectedErr: fmt.Errorf("solving day 3 part 3: invalid part 3, expected 1 or 2"),
+		},
+		{
+			name:        "missing input",
+			args:        []string{"run", "-day", "3", "-input", filepath.Join(dir, "missing.txt")},
+			expectedErr: fmt.Errorf("solving day 3 part 1: opening file: open %s: no such file or directory", filepath.Join(dir, "missing.txt")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var out, errOut bytes.Buffer
+			err := run(tc.args, &out, &errOut)
+
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, out.String())
+			assert.Equal(t, tc.expectedWarnings, errOut.String())
+		})
+	}
+}
+
+// TestRunOptionsReset checks the options given to a day's solver on one command line are gone by
+// the next, every solver being shared by the whole process.
+func TestRunOptionsReset(t *testing.T) {
+	dir := t.TempDir()
+	games := filepath.Join(dir, "day2.txt")
+	require.NoError(t, os.WriteFile(games, []byte("Game 1: 3 blue, 4 red\nGame 2: 1 blue, 2 red\n"), 0o600))
+	calibration := filepath.Join(dir, "day1.txt")
+	require.NoError(t, os.WriteFile(calibration, []byte("one2drei\n"), 0o600))
+	schematic := filepath.Join(dir, "day3.txt")
+	require.NoError(t, os.WriteFile(schematic, []byte("467..114..\n...*......\n..35..633.\n......#...\n"), 0o600))
+
+	testCases := []struct {
+		name string
+		// options changes the answer of the command line args gives
+		options  []string
+		args     []string
+		expected string
+	}{
+		{
+			name:     "day 2 bag",
+			options:  []string{"-bag", "red=1"},
+			args:     []string{"run", "-day", "2", "-part", "1", "-input", games},
+			expected: "day 2 part 1: 3\n",
+		},
+		{
+			name:     "day 1 locale",
+			options:  []string{"-locale", "de"},
+			args:     []string{"run", "-day", "1", "-part", "2", "-input", calibration},
+			expected: "day 1 part 2: 12\n",
+		},
+		{
+			name:     "day 3 dialect",
+			options:  []string{"-gear", "#"},
+			args:     []string{"run", "-day", "3", "-part", "2", "-input", schematic},
+			expected: "day 3 part 2: 16345\n",
+		},
+		{
+			name:     "day 3 rules",
+			options:  []string{"-rules", "#=sum"},
+			args:     []string{"run", "-day", "3", "-part", "2", "-input", schematic},
+			expected: "day 3 part 2: 16345\n",
+		},
+		{
+			name:    "day 3 crop",
+			options: []string{"-crop", "2"},
+			args:    []string{"run", "-day", "3", "-part", "1", "-render", "mono", "-input", schematic},
+			expected: `day 3 part 1: 1135
+[467]..(114)..
+...{*}......
+..[35]..[633].
+......<#>...
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var out, errOut bytes.Buffer
+			require.NoError(t, run(append(tc.args, tc.options...), &out, &errOut))
+			require.NotEqual(t, tc.expected, out.String(), "the options change the answer")
+
+			out.Reset()
+			require.NoError(t, run(tc.args, &out, &errOut))
+			assert.Equal(t, tc.expected, out.String())
+		})
+	}
+}
+
+func TestRunJSON(t *testing.T) {
+	dir := t.TempDir()
+	games := filepath.Join(dir, "day2.txt")
+	content := []byte("Game 1: 3 blue, 4 red\nGame 2: 1 blue, 2 yellow\n")
+	require.NoError(t, os.WriteFile(games, content, 0o600))
+	hash := fmt.Sprintf("%x", sha256.Sum256(content))
+
+	var out, errOut bytes.Buffer
+	require.NoError(t, run([]string{"run", "-day", "2", "-format", "json", "-input", games}, &out, &errOut))
+
+	warning := fmt.Sprintf("%s, line 2, column 17: unknown color \"yellow\"", games)
+	expected := []result{
+		{Day: 2, Part: 1, Answer: 3, Input: games, InputSHA256: hash, Warnings: []string{warning}},
+		{Day: 2, Part: 2, Answer: 13, Input: games, InputSHA256: hash, Warnings: []string{warning}},
+	}
+
+	var actual []result
+	dec := json.NewDecoder(&out)
+	for dec.More() {
+		var res result
+		require.NoError(t, dec.Decode(&res))
+		assert.GreaterOrEqual(t, res.ElapsedNS, int64(0))
+		res.ElapsedNS = 0
+		actual = append(actual, res)
+	}
+
+	assert.Equal(t, expected, actual)
+	assert.Empty(t, errOut.String(), "warnings are only part of the JSON")
+}
+
+func TestRunJSONSchema(t *testing.T) {
+	dir := t.TempDir()
+	schematic := filepath.Join(dir, "day3.txt")
+	require.NoError(t, os.WriteFile(schematic, []byte("467..114..\n...*......\n..35..633.\n"), 0o600))
+
+	var out, errOut bytes.Buffer
+	require.NoError(t, run([]string{"run", "-day", "3", "-part", "1", "-format", "json", "-input", schematic}, &out, &errOut))
+
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &fields))
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	assert.ElementsMatch(t, []string{"day", "part", "answer", "input", "input_sha256", "elapsed_ns", "warnings"}, keys)
+	assert.Equal(t, []any{}, fields["warnings"], "days without warnings still give a list")
+}
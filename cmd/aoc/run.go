@@ -0,0 +1,180 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mxygem/advent-of-code-2023/aoc"
+)
+
+// result is the outcome of solving one part of a puzzle, printed as a single line of JSON with
+// -format json.
+type result struct {
+	Day         int        `json:"day"`
+	Part        int        `json:"part"`
+	Answer      aoc.Answer `json:"answer"`
+	Input       string     `json:"input"`
+	InputSHA256 string     `json:"input_sha256"`
+	ElapsedNS   int64      `json:"elapsed_ns"`
+	Warnings    []string   `json:"warnings"`
+}
+
+// runCmd solves the requested day and part, or both parts of the day when no part is given, and
+// prints one line per answer, each preceded by any explanation and followed by any report the solver
+// gives. Any warnings raised by the solver are printed to errOut. With -format json every answer is
+// printed as a JSON object holding its own warnings instead, and explanations and reports go to
+// errOut so out is left holding only JSON.
+func runCmd(args []string, out, errOut io.Writer) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	fs.SetOutput(errOut)
+
+	var day, part, workers int
+	var inputLoc, format string
+
+	fs.IntVar(&day, "day", 0, "day of the puzzle to solve")
+	fs.IntVar(&part, "part", 0, "part of the puzzle to solve, both parts are run when unset")
+	fs.StringVar(&inputLoc, "input", "", "specify location of input file, defaults to the day's puzzle_input.txt")
+	fs.IntVar(&workers, "workers", 1, "number of goroutines evaluating input lines, for days that support it")
+	fs.StringVar(&format, "format", "text", "output format, text or json")
+
+	aoc.RegisterFlags(fs)
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	if day == 0 {
+		return fmt.Errorf("no day given")
+	}
+
+	if format != "text" && format != "json" {
+		return fmt.Errorf("unknown format %q, expected text or json", format)
+	}
+
+	if inputLoc == "" {
+		inputLoc = defaultInput(day)
+	}
+
+	s, err := aoc.Lookup(day)
+	if err != nil {
+		return err
+	}
+
+	if workers < 1 {
+		return fmt.Errorf("invalid number of workers %d, expected at least 1", workers)
+	}
+	if p, ok := s.(aoc.Parallel); ok {
+		p.SetWorkers(workers)
+	} else if workers > 1 {
+		return fmt.Errorf("day %d does not support -workers", day)
+	}
+
+	parts := []int{part}
+	if part == 0 {
+		parts = []int{1, 2}
+	}
+
+	reportOut := out
+	if format == "json" {
+		reportOut = errOut
+	}
+	enc := json.NewEncoder(out)
+
+	if e, ok := s.(aoc.Explainer); ok {
+		e.ExplainTo(reportOut)
+	}
+
+	for _, p := range parts {
+		res, err := solvePart(s, p, inputLoc)
+		if err != nil {
+			return fmt.Errorf("solving day %d part %d: %w", day, p, err)
+		}
+		res.Day = day
+
+		if format == "json" {
+			res.Warnings = warnings(s, inputLoc)
+			if err := enc.Encode(res); err != nil {
+				return fmt.Errorf("writing day %d part %d: %w", day, p, err)
+			}
+			continue
+		}
+
+		fmt.Fprintf(out, "day %d part %d: %d\n", day, p, res.Answer)
+	}
+
+	if rep, ok := s.(aoc.Reporter); ok {
+		if err := rep.Report(reportOut); err != nil {
+			return fmt.Errorf("reporting day %d: %w", day, err)
+		}
+	}
+
+	if format == "text" {
+		// every part reads the same input so the warnings of the last one cover them all
+		for _, warning := range warnings(s, inputLoc) {
+			fmt.Fprintf(errOut, "warning: %s\n", warning)
+		}
+	}
+
+	return nil
+}
+
+// warnings returns the warnings raised by s while solving the most recent part, located in
+// inputLoc. It never returns nil so JSON output always holds a list.
+func warnings(s aoc.Solver, inputLoc string) []string {
+	out := []string{}
+	if w, ok := s.(aoc.Warner); ok {
+		for _, warning := range w.Warnings() {
+			out = append(out, aoc.InFile(warning, inputLoc).Error())
+		}
+	}
+
+	return out
+}
+
+// solvePart opens the input at inputLoc and hands it to the requested part of s, timing how long it
+// takes and hashing the input as it is read.
+func solvePart(s aoc.Solver, part int, inputLoc string) (*result, error) {
+	f, err := os.Open(inputLoc)
+	if err != nil {
+		return nil, fmt.Errorf("opening file: %w", err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	start := time.Now()
+	answer, err := aoc.Solve(s, part, io.TeeReader(f, h))
+	elapsed := time.Since(start)
+	if err != nil {
+		return nil, aoc.InFile(err, inputLoc)
+	}
+
+	// solvers may stop reading once they have their answer
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, fmt.Errorf("hashing input: %w", err)
+	}
+
+	return &result{
+		Part:        part,
+		Answer:      answer,
+		Input:       inputLoc,
+		InputSHA256: hex.EncodeToString(h.Sum(nil)),
+		ElapsedNS:   elapsed.Nanoseconds(),
+	}, nil
+}
+
+// defaultInput returns the conventional location of a day's puzzle input relative to the
+// repository root.
+func defaultInput(day int) string {
+	return filepath.Join(fmt.Sprintf("day-%02d", day), "puzzle_input.txt")
+}
@@ -0,0 +1,292 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mxygem/advent-of-code-2023/aoc"
+)
+
+// The outcomes of submitting an answer.
+const (
+	correct    = "correct"
+	tooHigh    = "too high"
+	tooLow     = "too low"
+	incorrect  = "incorrect"
+	tooRecent  = "wait"
+	wrongLevel = "wrong level"
+)
+
+// verdict is the site's response to a submitted answer. wait is how long the site asks to wait
+// before submitting again, both after an answer too recent and after a wrong one.
+type verdict struct {
+	outcome string
+	wait    time.Duration
+}
+
+var (
+	articlePattern = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagPattern     = regexp.MustCompile(`<[^>]*>`)
+	waitPattern    = regexp.MustCompile(`You have (?:(\d+)m )?(\d+)s left to wait`)
+	retryPattern   = regexp.MustCompile(`(?i)please wait (one|\d+) minutes? before trying again`)
+)
+
+// parseVerdict reads the verdict out of the page returned by the site after submitting an answer.
+func parseVerdict(page string) (verdict, error) {
+	m := articlePattern.FindStringSubmatch(page)
+	if m == nil {
+		return verdict{}, fmt.Errorf("no verdict found in response")
+	}
+	text := strings.Join(strings.Fields(tagPattern.ReplaceAllString(m[1], " ")), " ")
+
+	switch {
+	case strings.Contains(text, "That's the right answer"):
+		return verdict{outcome: correct}, nil
+	case strings.Contains(text, "That's not the right answer"):
+		v := verdict{outcome: incorrect}
+		switch {
+		case strings.Contains(text, "your answer is too high"):
+			v.outcome = tooHigh
+		case strings.Contains(text, "your answer is too low"):
+			v.outcome = tooLow
+		}
+		if w := retryPattern.FindStringSubmatch(text); w != nil {
+			mins, _ := strconv.Atoi(w[1])
+			v.wait = time.Duration(max(mins, 1)) * time.Minute
+		}
+		return v, nil
+	case strings.Contains(text, "You gave an answer too recently"):
+		w := waitPattern.FindStringSubmatch(text)
+		if w == nil {
+			return verdict{}, fmt.Errorf("no wait found in response %q", text)
+		}
+		mins, _ := strconv.Atoi(w[1])
+		secs, _ := strconv.Atoi(w[2])
+		return verdict{outcome: tooRecent, wait: time.Duration(mins)*time.Minute + time.Duration(secs)*time.Second}, nil
+	case strings.Contains(text, "You don't seem to be solving the right level"):
+		return verdict{outcome: wrongLevel}, nil
+	}
+
+	return verdict{}, fmt.Errorf("unrecognised response %q", text)
+}
+
+// answer submits ans as the answer to the given part of a day's puzzle, returning the site's
+// verdict.
+func (c *client) answer(day, part int, ans aoc.Answer) (verdict, error) {
+	form := url.Values{"level": {strconv.Itoa(part)}, "answer": {strconv.Itoa(int(ans))}}
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/%d/day/%d/answer", c.baseURL, year, day), strings.NewReader(form.Encode()))
+	if err != nil {
+		return verdict{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	page, err := c.do(req)
+	if err != nil {
+		return verdict{}, err
+	}
+
+	return parseVerdict(string(page))
+}
+
+// guess is an answer submitted for a part along with the site's verdict.
+type guess struct {
+	Part    int        `json:"part"`
+	Answer  aoc.Answer `json:"answer"`
+	Outcome string     `json:"outcome"`
+}
+
+// guesses holds every answer of a day judged by the site, so known wrong answers are never
+// submitted again.
+type guesses []guess
+
+// submissions is the record of a day's submitted answers kept in a JSON file: the guesses judged so
+// far and, while the site is rate limiting answers, the time before which it takes no other.
+type submissions struct {
+	NotBefore *time.Time `json:"not_before,omitempty"`
+	Guesses   guesses    `json:"guesses,omitempty"`
+}
+
+// now is the current time, replaced in tests.
+var now = time.Now
+
+// loadSubmissions reads the submissions saved at loc, of which there are none if there is no file.
+func loadSubmissions(loc string) (*submissions, error) {
+	var subs submissions
+	b, err := os.ReadFile(loc)
+	if errors.Is(err, os.ErrNotExist) {
+		return &subs, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading guesses: %w", err)
+	}
+
+	if err := json.Unmarshal(b, &subs); err != nil {
+		return nil, fmt.Errorf("reading guesses from %s: %w", loc, err)
+	}
+
+	return &subs, nil
+}
+
+// waitFor records that the site takes no answer until d has passed.
+func (subs *submissions) waitFor(d time.Duration) {
+	t := now().Add(d).UTC()
+	subs.NotBefore = &t
+}
+
+// save writes the submissions to loc.
+func (subs *submissions) save(loc string) error {
+	b, err := json.MarshalIndent(subs, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(loc, append(b, '\n'), 0o644); err != nil {
+		return fmt.Errorf("saving guesses: %w", err)
+	}
+
+	return nil
+}
+
+// judge returns the verdict the earlier guesses already give ans for the given part, if any. An
+// answer is known to be too high when it is no lower than a guess found too high, and likewise too
+// low.
+func (gs guesses) judge(part int, ans aoc.Answer) (guess, bool) {
+	for _, g := range gs {
+		if g.Part != part {
+			continue
+		}
+
+		switch {
+		case g.Outcome == correct,
+			g.Answer == ans,
+			g.Outcome == tooHigh && ans >= g.Answer,
+			g.Outcome == tooLow && ans <= g.Answer:
+			return g, true
+		}
+	}
+
+	return guess{}, false
+}
+
+// submitCmd solves the requested part of a day's puzzle and submits the answer, unless earlier
+// guesses show it is wrong or the part is already solved. Every judged answer is added to the day's
+// guesses along with any wait the site asks for, and nothing is submitted until that wait is over.
+// Answers found wrong, submissions made too soon and answers to a level the site is not taking are
+// returned as errors.
+func submitCmd(args []string, out, errOut io.Writer) error {
+	fs := flag.NewFlagSet("submit", flag.ContinueOnError)
+	fs.SetOutput(errOut)
+
+	var day, part int
+	var inputLoc, guessesLoc string
+
+	fs.IntVar(&day, "day", 0, "day of the puzzle to submit an answer to")
+	fs.IntVar(&part, "part", 0, "part of the puzzle to submit an answer to")
+	fs.StringVar(&inputLoc, "input", "", "specify location of input file, defaults to the day's puzzle_input.txt")
+	fs.StringVar(&guessesLoc, "guesses", "", "location of the record of submitted answers, defaults to the day's guesses.json")
+	cfg := clientFlags(fs)
+
+	aoc.RegisterFlags(fs)
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	if day == 0 {
+		return fmt.Errorf("no day given")
+	}
+	if part != 1 && part != 2 {
+		return fmt.Errorf("invalid part %d, expected 1 or 2", part)
+	}
+
+	if inputLoc == "" {
+		inputLoc = defaultInput(day)
+	}
+	if guessesLoc == "" {
+		guessesLoc = filepath.Join(filepath.Dir(defaultInput(day)), "guesses.json")
+	}
+
+	s, err := aoc.Lookup(day)
+	if err != nil {
+		return err
+	}
+
+	res, err := solvePart(s, part, inputLoc)
+	if err != nil {
+		return fmt.Errorf("solving day %d part %d: %w", day, part, err)
+	}
+
+	subs, err := loadSubmissions(guessesLoc)
+	if err != nil {
+		return err
+	}
+
+	if known, ok := subs.Guesses.judge(part, res.Answer); ok {
+		switch {
+		case known.Outcome == correct && known.Answer == res.Answer:
+			fmt.Fprintf(out, "day %d part %d: %d is correct, already submitted\n", day, part, res.Answer)
+			return nil
+		case known.Outcome == correct:
+			return fmt.Errorf("day %d part %d: %d is wrong, %d was found correct", day, part, res.Answer, known.Answer)
+		case known.Answer == res.Answer:
+			return fmt.Errorf("day %d part %d: %d was already submitted and found %s", day, part, res.Answer, known.Outcome)
+		default:
+			return fmt.Errorf("day %d part %d: %d is %s, %d was found %[4]s", day, part, res.Answer, known.Outcome, known.Answer)
+		}
+	}
+
+	if subs.NotBefore != nil && now().Before(*subs.NotBefore) {
+		return fmt.Errorf("day %d part %d: answered too recently, wait %s before submitting again", day, part, subs.NotBefore.Sub(now()).Round(time.Second))
+	}
+
+	c, err := cfg.newClient()
+	if err != nil {
+		return err
+	}
+
+	v, err := c.answer(day, part, res.Answer)
+	if err != nil {
+		return fmt.Errorf("submitting day %d part %d: %w", day, part, err)
+	}
+
+	if v.outcome == wrongLevel {
+		return fmt.Errorf("day %d part %d: the site is not taking answers to this part, it is either solved already or still locked", day, part)
+	}
+
+	subs.NotBefore = nil
+	if v.wait > 0 {
+		subs.waitFor(v.wait)
+	}
+	if v.outcome != tooRecent {
+		subs.Guesses = append(subs.Guesses, guess{Part: part, Answer: res.Answer, Outcome: v.outcome})
+	}
+	if err := subs.save(guessesLoc); err != nil {
+		return err
+	}
+
+	switch v.outcome {
+	case tooRecent:
+		return fmt.Errorf("day %d part %d: answered too recently, wait %s before submitting again", day, part, v.wait)
+	case correct:
+		fmt.Fprintf(out, "day %d part %d: %d is correct\n", day, part, res.Answer)
+		return nil
+	case incorrect:
+		return fmt.Errorf("day %d part %d: %d is not the right answer", day, part, res.Answer)
+	default:
+		return fmt.Errorf("day %d part %d: %d is %s", day, part, res.Answer, v.outcome)
+	}
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mxygem/advent-of-code-2023/aoc"
+)
+
+// page wraps the verdict in an article the way the site does.
+func page(article string) string {
+	return `<!DOCTYPE html><html><body><main>
+<article><p>` + article + `</p></article>
+</main></body></html>`
+}
+
+func TestParseVerdict(t *testing.T) {
+	testCases := []struct {
+		name        string
+		page        string
+		expected    verdict
+		expectedErr error
+	}{
+		{
+			name:     "correct",
+			page:     page(`That's the right answer!  You are <span class="day-success">one gold star</span> closer to restoring snow operations. <a href="/2023/day/3#part2">[Continue to Part Two]</a>`),
+			expected: verdict{outcome: correct},
+		},
+		{
+			name:     "too high",
+			page:     page(`That's not the right answer; your answer is too high.  If you're stuck, make sure you're using the full input data; please wait one minute before trying again. <a href="/2023/day/3">[Return to Day 3]</a>`),
+			expected: verdict{outcome: tooHigh, wait: time.Minute},
+		},
+		{
+			name:     "too low",
+			page:     page(`That's not the right answer; your answer is too low.  Please wait one minute before trying again.`),
+			expected: verdict{outcome: tooLow, wait: time.Minute},
+		},
+		{
+			name:     "no hint",
+			page:     page(`That's not the right answer.  If you're stuck, make sure you're using the full input data.`),
+			expected: verdict{outcome: incorrect},
+		},
+		{
+			name:     "minutes before trying again",
+			page:     page(`That's not the right answer.  Because you have guessed incorrectly 6 times on this puzzle, please wait 5 minutes before trying again.`),
+			expected: verdict{outcome: incorrect, wait: 5 * time.Minute},
+		},
+		{
+			name:     "seconds to wait",
+			page:     page(`You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 34s left to wait.`),
+			expected: verdict{outcome: tooRecent, wait: 34 * time.Second},
+		},
+		{
+			name:     "minutes to wait",
+			page:     page(`You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 4m 2s left to wait.`),
+			expected: verdict{outcome: tooRecent, wait: 4*time.Minute + 2*time.Second},
+		},
+		{
+			name:     "wrong level",
+			page:     page(`You don't seem to be solving the right level.  Did you already complete it? <a href="/2023/day/3">[Return to Day 3]</a>`),
+			expected: verdict{outcome: wrongLevel},
+		},
+		{
+			name:        "unrecognised",
+			page:        page(`Something <em>else</em> happened.`),
+			expectedErr: fmt.Errorf(`unrecognised response "Something else happened."`),
+		},
+		{
+			name:        "no article",
+			page:        "<html></html>",
+			expectedErr: fmt.Errorf("no verdict found in response"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := parseVerdict(tc.page)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestGuessesJudge(t *testing.T) {
+	gs := guesses{
+		{Part: 1, Answer: 500, Outcome: tooHigh},
+		{Part: 1, Answer: 100, Outcome: tooLow},
+		{Part: 1, Answer: 250, Outcome: incorrect},
+		{Part: 2, Answer: 42, Outcome: correct},
+	}
+
+	testCases := []struct {
+		name     string
+		part     int
+		answer   int
+		expected *guess
+	}{
+		{name: "untried", part: 1, answer: 300},
+		{name: "same as a wrong guess", part: 1, answer: 250, expected: &gs[2]},
+		{name: "above a guess too high", part: 1, answer: 501, expected: &gs[0]},
+		{name: "below a guess too low", part: 1, answer: 7, expected: &gs[1]},
+		{name: "solved part", part: 2, answer: 43, expected: &gs[3]},
+		{name: "bounds of other parts", part: 2, answer: 700, expected: &gs[3]},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, ok := gs.judge(tc.part, aoc.Answer(tc.answer))
+			if tc.expected == nil {
+				assert.False(t, ok)
+				return
+			}
+
+			require.True(t, ok)
+			assert.Equal(t, *tc.expected, actual)
+		})
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	submitted := time.Date(2023, 12, 3, 6, 0, 0, 0, time.UTC)
+	now = func() time.Time { return submitted }
+	t.Cleanup(func() { now = time.Now })
+
+	dir := t.TempDir()
+	schematic := filepath.Join(dir, "day3.txt")
+	require.NoError(t, os.WriteFile(schematic, []byte("467..114..\n...*......\n..35..633.\n"), 0o600))
+
+	var response string
+	var form map[string]string
+	site := newStubSite(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2023/day/3/answer" {
+			http.NotFound(w, r)
+			return
+		}
+		form = map[string]string{"level": r.PostFormValue("level"), "answer": r.PostFormValue("answer")}
+		fmt.Fprint(w, page(response))
+	})
+
+	testCases := []struct {
+		name             string
+		part             int
+		guesses          string
+		response         string
+		expected         string
+		expectedGuesses  string
+		expectedRequests int
+		expectedErr      error
+	}{
+		{
+			name:             "correct",
+			part:             2,
+			response:         "That's the right answer!",
+			expected:         "day 3 part 2: 16345 is correct\n",
+			expectedGuesses:  `{"guesses":[{"part":2,"answer":16345,"outcome":"correct"}]}`,
+			expectedRequests: 1,
+		},
+		{
+			name:             "too high",
+			part:             1,
+			guesses:          `{"guesses":[{"part":2,"answer":16345,"outcome":"correct"}]}`,
+			response:         "That's not the right answer; your answer is too high.  Please wait one minute before trying again.",
+			expectedGuesses:  `{"not_before":"2023-12-03T06:01:00Z","guesses":[{"part":2,"answer":16345,"outcome":"correct"},{"part":1,"answer":502,"outcome":"too high"}]}`,
+			expectedRequests: 1,
+			expectedErr:      fmt.Errorf("day 3 part 1: 502 is too high"),
+		},
+		{
+			name:            "known wrong answers are not submitted",
+			part:            1,
+			guesses:         `{"guesses":[{"part":1,"answer":502,"outcome":"incorrect"}]}`,
+			expectedGuesses: `{"guesses":[{"part":1,"answer":502,"outcome":"incorrect"}]}`,
+			expectedErr:     fmt.Errorf("day 3 part 1: 502 was already submitted and found incorrect"),
+		},
+		{
+			name:            "answers beyond a known bound are not submitted",
+			part:            1,
+			guesses:         `{"guesses":[{"part":1,"answer":600,"outcome":"too low"}]}`,
+			expectedGuesses: `{"guesses":[{"part":1,"answer":600,"outcome":"too low"}]}`,
+			expectedErr:     fmt.Errorf("day 3 part 1: 502 is too low, 600 was found too low"),
+		},
+		{
+			name:            "solved parts are not submitted again",
+			part:            2,
+			guesses:         `{"guesses":[{"part":2,"answer":16345,"outcome":"correct"}]}`,
+			expected:        "day 3 part 2: 16345 is correct, already submitted\n",
+			expectedGuesses: `{"guesses":[{"part":2,"answer":16345,"outcome":"correct"}]}`,
+		},
+		{
+			name:            "answers other than the solution are not submitted",
+			part:            2,
+			guesses:         `{"guesses":[{"part":2,"answer":1,"outcome":"correct"}]}`,
+			expectedGuesses: `{"guesses":[{"part":2,"answer":1,"outcome":"correct"}]}`,
+			expectedErr:     fmt.Errorf("day 3 part 2: 16345 is wrong, 1 was found correct"),
+		},
+		{
+			name:             "too recent",
+			part:             1,
+			response:         "You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 1m 5s left to wait.",
+			expectedGuesses:  `{"not_before":"2023-12-03T06:01:05Z"}`,
+			expectedRequests: 1,
+			expectedErr:      fmt.Errorf("day 3 part 1: answered too recently, wait 1m5s before submitting again"),
+		},
+		{
+			name:            "nothing is submitted before the wait is over",
+			part:            1,
+			guesses:         `{"not_before":"2023-12-03T06:00:30Z","guesses":[{"part":1,"answer":7,"outcome":"too low"}]}`,
+			expectedGuesses: `{"not_before":"2023-12-03T06:00:30Z","guesses":[{"part":1,"answer":7,"outcome":"too low"}]}`,
+			expectedErr:     fmt.Errorf("day 3 part 1: answered too recently, wait 30s before submitting again"),
+		},
+		{
+			name:             "submitted once the wait is over",
+			part:             1,
+			guesses:          `{"not_before":"2023-12-03T05:59:00Z","guesses":[{"part":1,"answer":7,"outcome":"too low"}]}`,
+			response:         "That's the right answer!",
+			expected:         "day 3 part 1: 502 is correct\n",
+			expectedGuesses:  `{"guesses":[{"part":1,"answer":7,"outcome":"too low"},{"part":1,"answer":502,"outcome":"correct"}]}`,
+			expectedRequests: 1,
+		},
+		{
+			name:             "solved elsewhere or locked",
+			part:             2,
+			response:         "You don't seem to be solving the right level.  Did you already complete it?",
+			expectedRequests: 1,
+			expectedErr:      fmt.Errorf("day 3 part 2: the site is not taking answers to this part, it is either solved already or still locked"),
+		},
+		{
+			name:        "no part",
+			expectedErr: fmt.Errorf("invalid part 0, expected 1 or 2"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(sessionEnv, "token")
+			site.requests, response, form = 0, tc.response, nil
+
+			guessesLoc := filepath.Join(t.TempDir(), "guesses.json")
+			if tc.guesses != "" {
+				require.NoError(t, os.WriteFile(guessesLoc, []byte(tc.guesses), 0o600))
+			}
+
+			args := []string{"submit", "-base-url", site.URL, "-day", "3", "-part", fmt.Sprint(tc.part), "-input", schematic, "-guesses", guessesLoc}
+			var out, errOut bytes.Buffer
+			err := run(args, &out, &errOut)
+			assert.Equal(t, tc.expectedRequests, site.requests)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expected, out.String())
+			}
+
+			if tc.expectedRequests > 0 {
+				assert.Equal(t, fmt.Sprint(tc.part), form["level"])
+			}
+
+			if tc.expectedGuesses == "" {
+				assert.NoFileExists(t, guessesLoc)
+				return
+			}
+			saved, err := os.ReadFile(guessesLoc)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expectedGuesses, string(saved))
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/mxygem/advent-of-code-2023/aoc"
+)
+
+// ledger holds the known answers to a day's puzzle for its puzzle_input.txt, either part being left
+// out until it is known.
+type ledger struct {
+	Part1 *aoc.Answer `json:"part1,omitempty"`
+	Part2 *aoc.Answer `json:"part2,omitempty"`
+}
+
+// answer returns the known answer to the given part, if any.
+func (l *ledger) answer(part int) (aoc.Answer, bool) {
+	a := l.Part1
+	if part == 2 {
+		a = l.Part2
+	}
+	if a == nil {
+		return 0, false
+	}
+
+	return *a, true
+}
+
+// ledgerLoc returns the location of a day's answers under the repository root.
+func ledgerLoc(root string, day int) string {
+	return filepath.Join(root, fmt.Sprintf("day-%02d", day), "answers.json")
+}
+
+// loadLedger reads the ledger at loc, returning nil if there is none.
+func loadLedger(loc string) (*ledger, error) {
+	b, err := os.ReadFile(loc)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading answers: %w", err)
+	}
+
+	var l ledger
+	if err := json.Unmarshal(b, &l); err != nil {
+		return nil, fmt.Errorf("reading answers from %s: %w", loc, err)
+	}
+
+	return &l, nil
+}
+
+// verifyCmd solves every part of every registered day with a known answer against the day's
+// puzzle_input.txt, printing a table comparing the answers. It fails if any answer differs from the
+// known one or cannot be worked out.
+func verifyCmd(args []string, out, errOut io.Writer) error {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	fs.SetOutput(errOut)
+
+	var root string
+	fs.StringVar(&root, "root", ".", "location of the repository holding the day directories")
+
+	aoc.RegisterFlags(fs)
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "day\tpart\texpected\tactual\tstatus")
+
+	var checked, failed int
+	for _, day := range aoc.Days() {
+		l, err := loadLedger(ledgerLoc(root, day))
+		if err != nil {
+			return err
+		}
+		if l == nil {
+			fmt.Fprintf(errOut, "day %d has no known answers\n", day)
+			continue
+		}
+
+		s, err := aoc.Lookup(day)
+		if err != nil {
+			return err
+		}
+
+		for _, part := range []int{1, 2} {
+			expected, ok := l.answer(part)
+			if !ok {
+				continue
+			}
+			checked++
+
+			res, err := solvePart(s, part, filepath.Join(root, defaultInput(day)))
+			switch {
+			case err != nil:
+				failed++
+				fmt.Fprintf(tw, "%d\t%d\t%d\t-\terror: %s\n", day, part, expected, err)
+			case res.Answer != expected:
+				failed++
+				fmt.Fprintf(tw, "%d\t%d\t%d\t%d\tchanged\n", day, part, expected, res.Answer)
+			default:
+				fmt.Fprintf(tw, "%d\t%d\t%d\t%d\tok\n", day, part, expected, res.Answer)
+			}
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("writing answers: %w", err)
+	}
+
+	switch {
+	case checked == 0:
+		return fmt.Errorf("no known answers found under %s", root)
+	case failed > 0:
+		return fmt.Errorf("%d of %d answers did not match", failed, checked)
+	}
+
+	return nil
+}
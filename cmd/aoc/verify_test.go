@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify(t *testing.T) {
+	testCases := []struct {
+		name             string
+		files            map[string]string
+		expected         string
+		expectedWarnings string
+		// expected and expectedErr refer to the repository root as {root}
+		expectedErr string
+	}{
+		{
+			name: "matching answers",
+			files: map[string]string{
+				"day-03/puzzle_input.txt": "467..114..\n...*......\n..35..633.\n",
+				"day-03/answers.json":     `{"part1": 502, "part2": 16345}`,
+			},
+			expected: `day  part  expected  actual  status
+3    1     502       502     ok
+3    2     16345     16345   ok
+`,
+			expectedWarnings: "day 1 has no known answers\nday 2 has no known answers\n",
+		},
+		{
+			name: "changed answer",
+			files: map[string]string{
+				"day-02/puzzle_input.txt": "Game 1: 3 blue, 4 red\nGame 2: 1 blue, 2 yellow\n",
+				"day-02/answers.json":     `{"part1": 4}`,
+				"day-03/puzzle_input.txt": "467..114..\n...*......\n..35..633.\n",
+				"day-03/answers.json":     `{"part2": 16345}`,
+			},
+			expected: `day  part  expected  actual  status
+2    1     4         3       changed
+3    2     16345     16345   ok
+`,
+			expectedWarnings: "day 1 has no known answers\n",
+			expectedErr:      "1 of 2 answers did not match",
+		},
+		{
+			name: "missing input",
+			files: map[string]string{
+				"day-01/answers.json": `{"part1": 142}`,
+			},
+			expected: `day  part  expected  actual  status
+1    1     142       -       error: opening file: open {root}/day-01/puzzle_input.txt: no such file or directory
+`,
+			expectedWarnings: "day 2 has no known answers\nday 3 has no known answers\n",
+			expectedErr:      "1 of 1 answers did not match",
+		},
+		{
+			name:             "no answers",
+			expected:         "day  part  expected  actual  status\n",
+			expectedWarnings: "day 1 has no known answers\nday 2 has no known answers\nday 3 has no known answers\n",
+			expectedErr:      "no known answers found under {root}",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			root := t.TempDir()
+			for name, content := range tc.files {
+				loc := filepath.Join(root, name)
+				require.NoError(t, os.MkdirAll(filepath.Dir(loc), 0o755))
+				require.NoError(t, os.WriteFile(loc, []byte(content), 0o600))
+			}
+
+			var out, errOut bytes.Buffer
+			err := run([]string{"verify", "-root", root}, &out, &errOut)
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				assert.Equal(t, strings.ReplaceAll(tc.expectedErr, "{root}", root), err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, strings.ReplaceAll(tc.expected, "{root}", root), out.String())
+			assert.Equal(t, tc.expectedWarnings, errOut.String())
+		})
+	}
+}
+
+func TestVerifyInvalidLedger(t *testing.T) {
+	root := t.TempDir()
+	loc := filepath.Join(root, "day-01", "answers.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(loc), 0o755))
+	require.NoError(t, os.WriteFile(loc, []byte(`{"part1": "many"}`), 0o600))
+
+	var out, errOut bytes.Buffer
+	err := run([]string{"verify", "-root", root}, &out, &errOut)
+
+	require.Error(t, err)
+	assert.Equal(t, fmt.Sprintf("reading answers from %s: json: cannot unmarshal string into Go struct field ledger.part1 of type aoc.Answer", loc), err.Error())
+}
+
+// TestVerifyRepository guards the answers checked in for every day's puzzle_input.txt.
+func TestVerifyRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("solves every day's full puzzle input")
+	}
+
+	var out, errOut bytes.Buffer
+	err := run([]string{"verify", "-root", filepath.Join("..", "..")}, &out, &errOut)
+
+	require.NoError(t, err, out.String())
+	assert.Empty(t, errOut.String(), "every day has known answers")
+}
@@ -0,0 +1,157 @@
+package day01
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/mxygem/advent-of-code-2023/aoc"
+)
+
+const (
+	_zeroRune byte = 48
+	_nineRune byte = 57
+)
+
+func init() {
+	aoc.Register(1, newSolver())
+}
+
+// solver recovers calibration values using only digits for part 1 and both digits and spelled
+// numbers for part 2. Spellings come from the vocabularies of the chosen locales along with any
+// found in the vocabulary file. When explaining, lines are worked through one at a time whatever
+// the number of workers.
+type solver struct {
+	locales    string
+	vocabFile  string
+	workers    int
+	explain    bool
+	explainOut io.Writer
+}
+
+func newSolver() *solver {
+	return &solver{locales: "en"}
+}
+
+func (s *solver) Flags(fs *flag.FlagSet) {
+	*s = *newSolver()
+
+	fs.StringVar(&s.locales, "locale", s.locales, fmt.Sprintf("day 1: comma separated spelled number vocabularies to use, any of %s", strings.Join(localeNames(), ", ")))
+	fs.StringVar(&s.vocabFile, "vocab", "", "day 1: location of a file of extra word=digit spellings")
+	fs.BoolVar(&s.explain, "explain", false, "day 1: describe the numbers found on every line and how they add to the total")
+}
+
+// ExplainTo sets where explanations are written when asked for.
+func (s *solver) ExplainTo(w io.Writer) {
+	s.explainOut = w
+}
+
+// explaining reports whether explanations were asked for and have somewhere to go.
+func (s *solver) explaining() bool {
+	return s.explain && s.explainOut != nil
+}
+
+// SetWorkers spreads the evaluation of the input's lines across n goroutines.
+func (s *solver) SetWorkers(n int) {
+	s.workers = n
+}
+
+// vocabulary builds the spellings recognized by part 2.
+func (s *solver) vocabulary() (vocabulary, error) {
+	v, err := localeVocabulary(s.locales)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.vocabFile != "" {
+		fv, err := loadVocabulary(s.vocabFile)
+		if err != nil {
+			return nil, err
+		}
+
+		v = append(v, fv...)
+	}
+
+	return v, nil
+}
+
+func (s *solver) Part1(r io.Reader) (aoc.Answer, error) {
+	if s.explaining() {
+		total, err := explainCalibration(r, newMatcher(nil), s.explainOut)
+		return aoc.Answer(total), err
+	}
+
+	total, err := calibration(r, parseDigits, s.workers)
+	return aoc.Answer(total), err
+}
+
+func (s *solver) Part2(r io.Reader) (aoc.Answer, error) {
+	vocab, err := s.vocabulary()
+	if err != nil {
+		return 0, err
+	}
+
+	if s.explaining() {
+		total, err := explainCalibration(r, newMatcher(vocab), s.explainOut)
+		return aoc.Answer(total), err
+	}
+
+	total, err := calibration(r, newMatcher(vocab).calibrationNumbers, s.workers)
+	return aoc.Answer(total), err
+}
+
+// calibration attempts to determine a calibration rate from a garbled series of lines, summing
+// together all numbers found across the lines by parse. Lines are streamed from r, spread across
+// the given number of workers, and one that cannot be read is reported as a ParseError.
+func calibration(r io.Reader, parse func(string) []string, workers int) (int, error) {
+	var total int
+	err := aoc.MapLines(r, workers, func(_ int, text string) int {
+		return calibrationValue(parse(text))
+	}, func(_ int, value int) {
+		total += value
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// parseDigits returns a collection of the digits found within the given line, ignoring spellings.
+func parseDigits(input string) []string {
+	var nums []string
+	for i := 0; i < len(input); i++ {
+		if input[i] >= _zeroRune && input[i] <= _nineRune {
+			nums = append(nums, string(input[i]))
+		}
+	}
+
+	return nums
+}
+
+// calibrationValue is responsible for returning a two-digit value to be used in calibration. If it
+// is unable to construct a suitable number or the number would be 00, it will return 0 instead.
+func calibrationValue(nums []string) int {
+	var found string
+
+	switch len(nums) {
+	case 0:
+		return 0
+	case 1:
+		// duplicate single numbers
+		found += nums[0]
+		found += nums[0]
+	default:
+		found += nums[0]
+		found += nums[len(nums)-1]
+	}
+
+	out, err := strconv.Atoi(found)
+	if err != nil {
+		return 0
+	}
+
+	return out
+}
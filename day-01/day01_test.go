@@ -1,16 +1,25 @@
-package main
+package day01
 
 import (
+	"errors"
+	"flag"
+	"io"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mxygem/advent-of-code-2023/aoc"
 )
 
-func TestCalibration(t *testing.T) {
+func TestSolver(t *testing.T) {
 	testCases := []struct {
-		name     string
-		input    string
-		expected int
+		name          string
+		input         string
+		expectedPart1 aoc.Answer
+		expectedPart2 aoc.Answer
 	}{
 		{
 			name: "part 1 example case",
@@ -18,7 +27,8 @@ func TestCalibration(t *testing.T) {
 			pqr3stu8vwx
 			a1b2c3d4e5f
 			treb7uchet`,
-			expected: 142,
+			expectedPart1: 142,
+			expectedPart2: 142,
 		},
 		{
 			name: "part 2 example case",
@@ -29,13 +39,101 @@ func TestCalibration(t *testing.T) {
 			4nineeightseven2
 			zoneight234
 			7pqrstsixteen`,
-			expected: 281,
+			expectedPart1: 209,
+			expectedPart2: 281,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			part1, err := newSolver().Part1(strings.NewReader(tc.input))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedPart1, part1)
+
+			part2, err := newSolver().Part2(strings.NewReader(tc.input))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedPart2, part2)
+		})
+	}
+}
+
+func TestCalibrationStreaming(t *testing.T) {
+	long := strings.Repeat("x", 3<<20)
+
+	testCases := []struct {
+		name        string
+		input       io.Reader
+		workers     int
+		expected    int
+		expectedErr error
+	}{
+		{
+			name:     "multi-megabyte line",
+			input:    strings.NewReader("1abc2\n" + long + "7" + long + "\n3xyz4"),
+			expected: 12 + 77 + 34,
+		},
+		{
+			name:     "multi-megabyte line across workers",
+			input:    strings.NewReader("1abc2\n" + long + "7" + long + "\n3xyz4"),
+			workers:  4,
+			expected: 12 + 77 + 34,
+		},
+		{
+			name:     "many lines across workers",
+			input:    strings.NewReader(strings.Repeat("1abc2\nx9y\n", 5000)),
+			workers:  4,
+			expected: 5000 * (12 + 99),
+		},
+		{
+			name:        "read failure is located",
+			workers:     4,
+			input:       io.MultiReader(strings.NewReader("1abc2\n3xyz4\n"), iotest.ErrReader(errors.New("disk on fire"))),
+			expectedErr: errors.New("line 3: reading line: disk on fire"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := calibration(tc.input, parseDigits, tc.workers)
+			if tc.expectedErr != nil {
+				var pe *aoc.ParseError
+				require.ErrorAs(t, err, &pe)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestParseDigits(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "empty",
+			input:    "",
+			expected: nil,
+		},
+		{
+			name:     "spellings are ignored",
+			input:    "two1nine",
+			expected: []string{"1"},
+		},
+		{
+			name:     "ensure 0 and 9 are read",
+			input:    "x9x1x0x",
+			expected: []string{"9", "1", "0"},
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			assert.Equal(t, tc.expected, calibration(tc.input))
+			assert.Equal(t, tc.expected, parseDigits(tc.input))
 		})
 	}
 }
@@ -130,7 +228,7 @@ func TestParseNumbers(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			assert.Equal(t, tc.expected, parseNumbers(tc.input))
+			assert.Equal(t, tc.expected, matchedDigits(newMatcher(english), tc.input))
 		})
 	}
 }
@@ -180,3 +278,15 @@ func TestCalibrationValue(t *testing.T) {
 		})
 	}
 }
+
+// configuredSolver returns a new solver with its flags parsed from args.
+func configuredSolver(t *testing.T, args ...string) *solver {
+	t.Helper()
+
+	s := newSolver()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	s.Flags(fs)
+	require.NoError(t, fs.Parse(args))
+
+	return s
+}
@@ -0,0 +1,62 @@
+package day01
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/mxygem/advent-of-code-2023/aoc"
+)
+
+// explainCalibration works out the same total as calibration while writing to w, for every line,
+// the numbers m finds in it along with their byte offsets, which of them the calibration value is
+// made from and the running total. Lines contributing nothing to the total are flagged.
+func explainCalibration(r io.Reader, m *matcher, w io.Writer) (int, error) {
+	bw := bufio.NewWriter(w)
+
+	var total int
+	err := aoc.ScanLines(r, func(line int, text string) {
+		found := m.matches(text)
+		first, last := -1, -1
+		var nums []string
+		if len(found) > 0 {
+			first, last = ends(found)
+			nums = []string{found[first].digit, found[last].digit}
+		}
+
+		value := calibrationValue(nums)
+		total += value
+
+		fmt.Fprintf(bw, "line %d %q: value %d, total %d", line, text, value, total)
+		if value == 0 {
+			fmt.Fprint(bw, " !! contributes zero")
+		}
+		fmt.Fprintln(bw)
+
+		for i, f := range found {
+			kind := "digit"
+			if f.spelled {
+				kind = "spelled"
+			}
+			fmt.Fprintf(bw, "  bytes [%d,%d) %-7s %q = %s", f.start, f.end, kind, text[f.start:f.end], f.digit)
+
+			if i == first {
+				fmt.Fprint(bw, ", first")
+			}
+			if i == last {
+				fmt.Fprint(bw, ", last")
+			}
+			fmt.Fprintln(bw)
+		}
+	})
+
+	// the writer keeps the first error it runs into
+	if flushErr := bw.Flush(); err == nil && flushErr != nil {
+		err = fmt.Errorf("writing explanation: %w", flushErr)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
@@ -0,0 +1,106 @@
+package day01
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainCalibration(t *testing.T) {
+	testCases := []struct {
+		name          string
+		vocab         vocabulary
+		input         string
+		expected      string
+		expectedTotal int
+	}{
+		{
+			name:  "digits only",
+			input: "a1b2c3\nxyz\n7",
+			expected: `line 1 "a1b2c3": value 13, total 13
+  bytes [1,2) digit   "1" = 1, first
+  bytes [3,4) digit   "2" = 2
+  bytes [5,6) digit   "3" = 3, last
+line 2 "xyz": value 0, total 13 !! contributes zero
+line 3 "7": value 77, total 90
+  bytes [0,1) digit   "7" = 7, first, last
+`,
+			expectedTotal: 90,
+		},
+		{
+			name:  "overlapping spellings",
+			vocab: english,
+			input: "eightwo3\nzero",
+			expected: `line 1 "eightwo3": value 83, total 83
+  bytes [0,5) spelled "eight" = 8, first
+  bytes [4,7) spelled "two" = 2
+  bytes [7,8) digit   "3" = 3, last
+line 2 "zero": value 0, total 83 !! contributes zero
+  bytes [0,4) spelled "zero" = 0, first, last
+`,
+			expectedTotal: 83,
+		},
+		{
+			name:  "multibyte spellings",
+			vocab: locales["fr"],
+			input: "zéro9",
+			expected: `line 1 "zéro9": value 9, total 9
+  bytes [0,5) spelled "zéro" = 0, first
+  bytes [5,6) digit   "9" = 9, last
+`,
+			expectedTotal: 9,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var out strings.Builder
+			total, err := explainCalibration(strings.NewReader(tc.input), newMatcher(tc.vocab), &out)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, out.String())
+			assert.Equal(t, tc.expectedTotal, total)
+
+			plain, err := calibration(strings.NewReader(tc.input), newMatcher(tc.vocab).calibrationNumbers, 1)
+			require.NoError(t, err)
+			assert.Equal(t, plain, total, "explaining does not change the total")
+		})
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestExplainCalibrationWriteFailure(t *testing.T) {
+	_, err := explainCalibration(strings.NewReader(strings.Repeat("1abc2\n", 1000)), newMatcher(nil), failingWriter{})
+
+	require.Error(t, err)
+	assert.Equal(t, "writing explanation: disk full", err.Error())
+}
+
+func TestSolverExplain(t *testing.T) {
+	input := "two1nine\nabc"
+
+	s := configuredSolver(t, "-explain")
+
+	part2, err := s.Part2(strings.NewReader(input))
+	require.NoError(t, err)
+	assert.EqualValues(t, 29, part2)
+
+	var out strings.Builder
+	s.ExplainTo(&out)
+
+	part1, err := s.Part1(strings.NewReader(input))
+	require.NoError(t, err)
+	assert.EqualValues(t, 11, part1)
+	assert.Equal(t, `line 1 "two1nine": value 11, total 11
+  bytes [3,4) digit   "1" = 1, first, last
+line 2 "abc": value 0, total 11 !! contributes zero
+`, out.String())
+}
@@ -0,0 +1,158 @@
+package day01
+
+import "sort"
+
+// match is a digit or spelled number found within a line, covering the bytes from start up to but
+// not including end.
+type match struct {
+	start, end int
+	digit      string
+	spelled    bool
+}
+
+// matcher finds every digit and every occurrence of a vocabulary's spellings within a line in a
+// single pass, using an Aho–Corasick automaton. Spellings may overlap each other by any amount.
+type matcher struct {
+	words []spelling
+	// next holds the automaton's transitions: next[state][b] is the state reached after reading
+	// byte b in state. State 0 is the root.
+	next [][256]int
+	// out holds, for every state, the indexes of the words ending at it.
+	out [][]int
+}
+
+// newMatcher builds the automaton recognizing every spelling of the vocabulary. Words spelled more
+// than once keep their first digit.
+func newMatcher(v vocabulary) *matcher {
+	m := &matcher{
+		next: make([][256]int, 1),
+		out:  make([][]int, 1),
+	}
+
+	// build the trie, using -1 for transitions not yet known
+	m.next[0] = emptyTransitions()
+	seen := map[string]bool{}
+	for _, sp := range v {
+		if sp.word == "" || seen[sp.word] {
+			continue
+		}
+		seen[sp.word] = true
+		m.words = append(m.words, sp)
+		w := len(m.words) - 1
+
+		state := 0
+		for i := 0; i < len(sp.word); i++ {
+			b := sp.word[i]
+			if m.next[state][b] < 0 {
+				m.next = append(m.next, emptyTransitions())
+				m.out = append(m.out, nil)
+				m.next[state][b] = len(m.next) - 1
+			}
+			state = m.next[state][b]
+		}
+		m.out[state] = append(m.out[state], w)
+	}
+
+	// walk the trie breadth first, filling in missing transitions from each state's failure link
+	// and inheriting the words that end at it
+	fail := make([]int, len(m.next))
+	var queue []int
+	for b := 0; b < 256; b++ {
+		switch s := m.next[0][b]; {
+		case s < 0:
+			m.next[0][b] = 0
+		default:
+			queue = append(queue, s)
+		}
+	}
+
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+
+		m.out[state] = append(m.out[state], m.out[fail[state]]...)
+
+		for b := 0; b < 256; b++ {
+			s := m.next[state][b]
+			if s < 0 {
+				m.next[state][b] = m.next[fail[state]][b]
+				continue
+			}
+
+			fail[s] = m.next[fail[state]][b]
+			queue = append(queue, s)
+		}
+	}
+
+	return m
+}
+
+func emptyTransitions() [256]int {
+	var t [256]int
+	for i := range t {
+		t[i] = -1
+	}
+
+	return t
+}
+
+// matches returns every digit and spelling found in line, ordered by where they start. Spellings
+// starting at the same byte are ordered longest first.
+func (m *matcher) matches(line string) []match {
+	var found []match
+
+	state := 0
+	for i := 0; i < len(line); i++ {
+		b := line[i]
+		if b >= _zeroRune && b <= _nineRune {
+			found = append(found, match{start: i, end: i + 1, digit: string(b)})
+		}
+
+		state = m.next[state][b]
+		for _, w := range m.out[state] {
+			sp := m.words[w]
+			found = append(found, match{start: i + 1 - len(sp.word), end: i + 1, digit: sp.digit, spelled: true})
+		}
+	}
+
+	// matches are found in order of where they end, so only those ending together or overlapping
+	// need moving
+	sort.SliceStable(found, func(i, j int) bool {
+		if found[i].start != found[j].start {
+			return found[i].start < found[j].start
+		}
+		return found[i].end > found[j].end
+	})
+
+	return found
+}
+
+// ends returns the indexes within found, ordered as matches orders them, of the number a line
+// starts with and of the one it ends with. Wherever several words start or end together the longest
+// of them wins, at either end of the line. found must not be empty.
+func ends(found []match) (first, last int) {
+	// the longest of the words starting first is already first
+	for i, f := range found {
+		if f.end > found[last].end {
+			last = i
+		}
+	}
+
+	return 0, last
+}
+
+// calibrationNumbers returns the numbers a line's calibration value is made from, the number the
+// line starts with followed by the one it ends with, or a single number when one word is both.
+func (m *matcher) calibrationNumbers(line string) []string {
+	found := m.matches(line)
+	if len(found) == 0 {
+		return nil
+	}
+
+	first, last := ends(found)
+	if first == last {
+		return []string{found[first].digit}
+	}
+
+	return []string{found[first].digit, found[last].digit}
+}
@@ -0,0 +1,276 @@
+package day01
+
+import (
+	"math/rand"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatches(t *testing.T) {
+	testCases := []struct {
+		name     string
+		vocab    vocabulary
+		input    string
+		expected []match
+		// expectedEnds holds the numbers the line starts and ends with
+		expectedEnds [2]string
+	}{
+		{
+			name:  "no matches",
+			vocab: english,
+			input: "abc",
+		},
+		{
+			name:  "digits and spellings",
+			vocab: english,
+			input: "x2twone",
+			expected: []match{
+				{start: 1, end: 2, digit: "2"},
+				{start: 2, end: 5, digit: "2", spelled: true},
+				{start: 4, end: 7, digit: "1", spelled: true},
+			},
+			expectedEnds: [2]string{"2", "1"},
+		},
+		{
+			name:  "overlapping by more than one letter",
+			vocab: vocabulary{{"abab", "1"}, {"babc", "2"}},
+			input: "ababc",
+			expected: []match{
+				{start: 0, end: 4, digit: "1", spelled: true},
+				{start: 1, end: 5, digit: "2", spelled: true},
+			},
+			expectedEnds: [2]string{"1", "2"},
+		},
+		{
+			name:  "word within another",
+			vocab: vocabulary{{"seven", "7"}, {"seventh", "7"}, {"even", "8"}},
+			input: "seventh",
+			expected: []match{
+				{start: 0, end: 7, digit: "7", spelled: true},
+				{start: 0, end: 5, digit: "7", spelled: true},
+				{start: 1, end: 5, digit: "8", spelled: true},
+			},
+			expectedEnds: [2]string{"7", "7"},
+		},
+		{
+			name:  "longest word wins at both ends",
+			vocab: vocabulary{{"one", "1"}, {"onex", "9"}},
+			input: "onex",
+			expected: []match{
+				{start: 0, end: 4, digit: "9", spelled: true},
+				{start: 0, end: 3, digit: "1", spelled: true},
+			},
+			expectedEnds: [2]string{"9", "9"},
+		},
+		{
+			name:  "longest word ending last",
+			vocab: vocabulary{{"one", "1"}, {"xone", "5"}},
+			input: "2xone",
+			expected: []match{
+				{start: 0, end: 1, digit: "2"},
+				{start: 1, end: 5, digit: "5", spelled: true},
+				{start: 2, end: 5, digit: "1", spelled: true},
+			},
+			expectedEnds: [2]string{"2", "5"},
+		},
+		{
+			name:  "repeated words match once",
+			vocab: vocabulary{{"six", "6"}, {"six", "9"}},
+			input: "six",
+			expected: []match{
+				{start: 0, end: 3, digit: "6", spelled: true},
+			},
+			expectedEnds: [2]string{"6", "6"},
+		},
+		{
+			name:  "multibyte spellings",
+			vocab: locales["fr"],
+			input: "zéro5",
+			expected: []match{
+				{start: 0, end: 5, digit: "0", spelled: true},
+				{start: 5, end: 6, digit: "5"},
+			},
+			expectedEnds: [2]string{"0", "5"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := newMatcher(tc.vocab).matches(tc.input)
+			assert.Equal(t, tc.expected, actual)
+
+			if len(actual) > 0 {
+				first, last := ends(actual)
+				assert.Equal(t, tc.expectedEnds, [2]string{actual[first].digit, actual[last].digit})
+			}
+		})
+	}
+}
+
+// TestMatchesAgreesWithScan checks the automaton against trying every spelling at every position of
+// random lines, for vocabularies full of overlapping words.
+func TestMatchesAgreesWithScan(t *testing.T) {
+	vocabs := map[string]vocabulary{
+		"english": english,
+		"every locale": func() vocabulary {
+			var v vocabulary
+			for _, n := range localeNames() {
+				v = append(v, locales[n]...)
+			}
+			return v
+		}(),
+		"overlapping": {{"aa", "1"}, {"aaa", "2"}, {"aba", "3"}, {"bab", "4"}, {"abab", "5"}},
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for name, v := range vocabs {
+		t.Run(name, func(t *testing.T) {
+			m := newMatcher(v)
+			alphabet := alphabetOf(v)
+
+			for i := 0; i < 500; i++ {
+				line := randomLine(rnd, alphabet, 40)
+				assert.Equal(t, scanMatches(m.words, line), m.matches(line), line)
+			}
+		})
+	}
+}
+
+// scanMatches is the straightforward equivalent of matcher.matches.
+func scanMatches(words []spelling, line string) []match {
+	var found []match
+	for i := 0; i < len(line); i++ {
+		var here []match
+		if line[i] >= _zeroRune && line[i] <= _nineRune {
+			here = append(here, match{start: i, end: i + 1, digit: string(line[i])})
+		}
+
+		for _, sp := range words {
+			if strings.HasPrefix(line[i:], sp.word) {
+				here = append(here, match{start: i, end: i + len(sp.word), digit: sp.digit, spelled: true})
+			}
+		}
+
+		// longest first, keeping vocabulary order for equal lengths
+		for len(here) > 0 {
+			longest := 0
+			for j, h := range here {
+				if h.end > here[longest].end {
+					longest = j
+				}
+			}
+			found = append(found, here[longest])
+			here = append(here[:longest], here[longest+1:]...)
+		}
+	}
+
+	return found
+}
+
+// matchedDigits returns the digit of every number the matcher finds in line, in order, or nil if it
+// finds none.
+func matchedDigits(m *matcher, line string) []string {
+	var digits []string
+	for _, f := range m.matches(line) {
+		digits = append(digits, f.digit)
+	}
+
+	return digits
+}
+
+// loopParseNumbers is the prefix checking loop the matcher replaced, kept to benchmark against.
+func loopParseNumbers(v vocabulary) func(string) []string {
+	return func(input string) []string {
+		line := strings.TrimSpace(input)
+		if line == "" {
+			return nil
+		}
+
+		var nums []string
+		for i := 0; i < len(line); i++ {
+			if line[i] >= _zeroRune && line[i] <= _nineRune {
+				nums = append(nums, string(line[i]))
+				continue
+			}
+
+			for _, sp := range v {
+				if !strings.HasPrefix(line[i:], sp.word) {
+					continue
+				}
+
+				nums = append(nums, sp.digit)
+				i += len(sp.word) - 2
+				break
+			}
+		}
+
+		return nums
+	}
+}
+
+func BenchmarkParseNumbers(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	var v vocabulary
+	for _, n := range localeNames() {
+		v = append(v, locales[n]...)
+	}
+
+	alphabet := alphabetOf(v) + "0123456789"
+	lines := make([]string, 1000)
+	for i := range lines {
+		lines[i] = randomLine(rnd, alphabet, 1000)
+	}
+	input := strings.Join(lines, "\n")
+
+	b.Run("loop", func(b *testing.B) {
+		parse := loopParseNumbers(v)
+		b.SetBytes(int64(len(input)))
+		for i := 0; i < b.N; i++ {
+			_, _ = calibration(strings.NewReader(input), parse, 1)
+		}
+	})
+
+	b.Run("automaton", func(b *testing.B) {
+		parse := newMatcher(v).calibrationNumbers
+		b.SetBytes(int64(len(input)))
+		for i := 0; i < b.N; i++ {
+			_, _ = calibration(strings.NewReader(input), parse, 1)
+		}
+	})
+
+	b.Run("automaton with workers", func(b *testing.B) {
+		parse := newMatcher(v).calibrationNumbers
+		b.SetBytes(int64(len(input)))
+		for i := 0; i < b.N; i++ {
+			_, _ = calibration(strings.NewReader(input), parse, runtime.GOMAXPROCS(0))
+		}
+	})
+}
+
+// alphabetOf returns every distinct byte used by the vocabulary's words.
+func alphabetOf(v vocabulary) string {
+	seen := map[byte]bool{}
+	var alphabet []byte
+	for _, sp := range v {
+		for i := 0; i < len(sp.word); i++ {
+			if !seen[sp.word[i]] {
+				seen[sp.word[i]] = true
+				alphabet = append(alphabet, sp.word[i])
+			}
+		}
+	}
+
+	return string(alphabet) + "x7"
+}
+
+func randomLine(rnd *rand.Rand, alphabet string, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = alphabet[rnd.Intn(len(alphabet))]
+	}
+
+	return string(b)
+}
@@ -0,0 +1,178 @@
+package day01
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/mxygem/advent-of-code-2023/aoc"
+)
+
+// spelling is a word standing in for a single digit.
+type spelling struct {
+	word, digit string
+}
+
+// vocabulary is the collection of spellings recognized when looking for numbers within a line.
+type vocabulary []spelling
+
+var (
+	english = vocabulary{
+		{"zero", "0"},
+		{"one", "1"},
+		{"two", "2"},
+		{"three", "3"},
+		{"four", "4"},
+		{"five", "5"},
+		{"six", "6"},
+		{"seven", "7"},
+		{"eight", "8"},
+		{"nine", "9"},
+	}
+
+	locales = map[string]vocabulary{
+		"en": english,
+		"en-ordinal": {
+			{"zeroth", "0"},
+			{"first", "1"},
+			{"second", "2"},
+			{"third", "3"},
+			{"fourth", "4"},
+			{"fifth", "5"},
+			{"sixth", "6"},
+			{"seventh", "7"},
+			{"eighth", "8"},
+			{"ninth", "9"},
+		},
+		"de": {
+			{"null", "0"},
+			{"eins", "1"},
+			{"zwei", "2"},
+			{"drei", "3"},
+			{"vier", "4"},
+			{"fünf", "5"},
+			{"sechs", "6"},
+			{"sieben", "7"},
+			{"acht", "8"},
+			{"neun", "9"},
+		},
+		"es": {
+			{"cero", "0"},
+			{"uno", "1"},
+			{"dos", "2"},
+			{"tres", "3"},
+			{"cuatro", "4"},
+			{"cinco", "5"},
+			{"seis", "6"},
+			{"siete", "7"},
+			{"ocho", "8"},
+			{"nueve", "9"},
+		},
+		"fr": {
+			{"zéro", "0"},
+			{"un", "1"},
+			{"deux", "2"},
+			{"trois", "3"},
+			{"quatre", "4"},
+			{"cinq", "5"},
+			{"six", "6"},
+			{"sept", "7"},
+			{"huit", "8"},
+			{"neuf", "9"},
+		},
+	}
+)
+
+// localeNames returns the names of every built in vocabulary in alphabetical order.
+func localeNames() []string {
+	names := make([]string, 0, len(locales))
+	for n := range locales {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// localeVocabulary combines the built in vocabularies of the given comma separated locales.
+func localeVocabulary(names string) (vocabulary, error) {
+	var v vocabulary
+	for _, n := range strings.Split(names, ",") {
+		n = strings.TrimSpace(n)
+		if n == "" {
+			continue
+		}
+
+		lv, ok := locales[n]
+		if !ok {
+			return nil, fmt.Errorf("unknown locale %q, expected one of %s", n, strings.Join(localeNames(), ", "))
+		}
+
+		v = append(v, lv...)
+	}
+
+	return v, nil
+}
+
+// loadVocabulary reads a vocabulary file from loc.
+func loadVocabulary(loc string) (vocabulary, error) {
+	f, err := os.Open(loc)
+	if err != nil {
+		return nil, fmt.Errorf("opening vocabulary: %w", err)
+	}
+	defer f.Close()
+
+	v, err := readVocabulary(f)
+	if err != nil {
+		return nil, aoc.InFile(err, loc)
+	}
+
+	return v, nil
+}
+
+// readVocabulary reads one word=digit spelling per line, such as "one=1". Blank lines and lines
+// starting with # are ignored.
+func readVocabulary(r io.Reader) (vocabulary, error) {
+	var v vocabulary
+	var errs []error
+	err := aoc.ScanLines(r, func(line int, raw string) {
+		text := strings.TrimSpace(raw)
+		if text == "" || strings.HasPrefix(text, "#") {
+			return
+		}
+
+		word, digit, ok := strings.Cut(text, "=")
+		word, digit = strings.TrimSpace(word), strings.TrimSpace(digit)
+
+		switch {
+		case !ok || word == "":
+			errs = append(errs, &aoc.ParseError{
+				Line:   line,
+				Column: 1,
+				Token:  text,
+				Err:    fmt.Errorf("invalid spelling %q, expected word=digit", text),
+			})
+		case len(digit) != 1 || digit[0] < _zeroRune || digit[0] > _nineRune:
+			errs = append(errs, &aoc.ParseError{
+				Line:   line,
+				Column: strings.Index(raw, "=") + 2,
+				Token:  digit,
+				Err:    fmt.Errorf("invalid digit %q for %q", digit, word),
+			})
+		default:
+			v = append(v, spelling{word: word, digit: digit})
+		}
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return v, nil
+}
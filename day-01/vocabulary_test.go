@@ -0,0 +1,144 @@
+package day01
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mxygem/advent-of-code-2023/aoc"
+)
+
+func TestLocaleVocabulary(t *testing.T) {
+	testCases := []struct {
+		name        string
+		locales     string
+		input       string
+		expected    []string
+		expectedErr error
+	}{
+		{
+			name:     "english",
+			locales:  "en",
+			input:    "xtwone3four",
+			expected: []string{"2", "1", "3", "4"},
+		},
+		{
+			name:     "english ordinals",
+			locales:  "en-ordinal",
+			input:    "firstxx7ninth",
+			expected: []string{"1", "7", "9"},
+		},
+		{
+			name:     "german",
+			locales:  "de",
+			input:    "zweiundfünfzig",
+			expected: []string{"2", "5"},
+		},
+		{
+			name:     "spanish",
+			locales:  "es",
+			input:    "cuatro8nueve",
+			expected: []string{"4", "8", "9"},
+		},
+		{
+			name:     "french",
+			locales:  "fr",
+			input:    "zérohuit",
+			expected: []string{"0", "8"},
+		},
+		{
+			name:     "combined",
+			locales:  "en, en-ordinal",
+			input:    "onesecond",
+			expected: []string{"1", "2"},
+		},
+		{
+			name:        "unknown",
+			locales:     "en,xx",
+			expectedErr: fmt.Errorf(`unknown locale "xx", expected one of de, en, en-ordinal, es, fr`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := localeVocabulary(tc.locales)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, matchedDigits(newMatcher(v), tc.input))
+		})
+	}
+}
+
+func TestReadVocabulary(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       string
+		expected    vocabulary
+		expectedErr error
+	}{
+		{
+			name:     "empty",
+			input:    "",
+			expected: nil,
+		},
+		{
+			name: "spellings with comments and blank lines",
+			input: `# dutch
+			een=1
+
+			twee = 2`,
+			expected: vocabulary{{"een", "1"}, {"twee", "2"}},
+		},
+		{
+			name:        "missing separator",
+			input:       "een 1",
+			expectedErr: fmt.Errorf(`line 1, column 1: invalid spelling "een 1", expected word=digit`),
+		},
+		{
+			name: "not a single digit",
+			input: `tien=10
+			drie=three`,
+			expectedErr: fmt.Errorf(`line 1, column 6: invalid digit "10" for "tien"
+line 2, column 9: invalid digit "three" for "drie"`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := readVocabulary(strings.NewReader(tc.input))
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestSolverVocabularyFile(t *testing.T) {
+	loc := filepath.Join(t.TempDir(), "dutch.txt")
+	require.NoError(t, os.WriteFile(loc, []byte("een=1\ntwee=2\ndrie=3\n"), 0o600))
+
+	s := configuredSolver(t, "-locale", "", "-vocab", loc)
+
+	actual, err := s.Part2(strings.NewReader("xeenx4\ntweedrie\nninetwee"))
+	require.NoError(t, err)
+	assert.Equal(t, aoc.Answer(14+23+22), actual)
+
+	s.vocabFile = filepath.Join(t.TempDir(), "missing.txt")
+	_, err = s.Part2(strings.NewReader("one"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
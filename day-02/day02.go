@@ -0,0 +1,512 @@
+package day02
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mxygem/advent-of-code-2023/aoc"
+)
+
+func init() {
+	aoc.Register(2, newSolver())
+}
+
+// solver sums the IDs of the games possible with the bag's contents for part 1 and the power of
+// each game's minimum set of cubes for part 2.
+type solver struct {
+	bag      set
+	palette  palette
+	strict   bool
+	workers  int
+	warnings []error
+}
+
+func newSolver() *solver {
+	return &solver{
+		bag:     set{"red": 12, "green": 13, "blue": 14},
+		palette: palette{"red", "green", "blue"},
+	}
+}
+
+func (s *solver) Flags(fs *flag.FlagSet) {
+	*s = *newSolver()
+
+	fs.Var(&s.bag, "bag", "day 2: cubes loaded into the bag as comma separated color=count pairs")
+	fs.Var(&s.palette, "colors", "day 2: comma separated cube colors that may appear in game records and the bag")
+	fs.BoolVar(&s.strict, "strict", false, "day 2: reject questionable game records instead of warning about them")
+}
+
+// SetWorkers spreads the parsing of the input's game records across n goroutines.
+func (s *solver) SetWorkers(n int) {
+	s.workers = n
+}
+
+// Warnings returns the questionable records tolerated while parsing the most recent input.
+func (s *solver) Warnings() []error {
+	return s.warnings
+}
+
+func (s *solver) Part1(r io.Reader) (aoc.Answer, error) {
+	if err := s.checkBag(); err != nil {
+		return 0, err
+	}
+
+	var idSum int
+	err := s.eachGame(r, func(g *game) {
+		if possibleGame(s.bag, g) {
+			idSum += g.id
+		}
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return aoc.Answer(idSum), nil
+}
+
+func (s *solver) Part2(r io.Reader) (aoc.Answer, error) {
+	var powerSum int
+	err := s.eachGame(r, func(g *game) {
+		powerSum += minimumGamePower(g)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return aoc.Answer(powerSum), nil
+}
+
+// checkBag returns an error if the bag holds cubes of a color outside the palette, as those cubes
+// would be dropped from every game record and games holding too many of them counted as possible.
+func (s *solver) checkBag() error {
+	for _, color := range s.bag.colors() {
+		if !s.palette.contains(color) {
+			return fmt.Errorf("bag holds %s cubes, which are not among the colors %s given by -colors", color, s.palette.String())
+		}
+	}
+
+	return nil
+}
+
+// eachGame streams the games recorded in r to fn, keeping the warnings raised along the way.
+func (s *solver) eachGame(r io.Reader, fn func(*game)) error {
+	p := &parser{strict: s.strict, palette: s.palette, workers: s.workers}
+	err := p.parseGames(r, fn)
+	s.warnings = p.warnings
+
+	return err
+}
+
+// parser turns game records into games. Malformed records are always rejected, while questionable
+// ones such as unknown colors or out of sequence IDs are rejected in strict mode and otherwise
+// parsed as usual and noted in warnings.
+type parser struct {
+	strict   bool
+	palette  palette
+	workers  int
+	warnings []error
+}
+
+// questionable handles a record that can be parsed but probably should not have been written the
+// way it was, adding it to errs in strict mode and to the parser's warnings otherwise. It reports
+// whether parsing should carry on as usual.
+func (p *parser) questionable(errs *[]error, pe *aoc.ParseError) bool {
+	if p.strict {
+		*errs = append(*errs, pe)
+		return false
+	}
+
+	p.warnings = append(p.warnings, pe)
+
+	return true
+}
+
+type game struct {
+	id   int
+	sets []set
+}
+
+// set holds the number of cubes of each color seen together.
+type set map[string]int
+
+// colors returns the colors of the set in order.
+func (s set) colors() []string {
+	colors := make([]string, 0, len(s))
+	for c := range s {
+		colors = append(colors, c)
+	}
+	sort.Strings(colors)
+
+	return colors
+}
+
+// String formats the set in the same color=count form accepted by Set, ordered by color.
+func (s *set) String() string {
+	colors := s.colors()
+	pairs := make([]string, len(colors))
+	for i, c := range colors {
+		pairs[i] = fmt.Sprintf("%s=%d", c, (*s)[c])
+	}
+
+	return strings.Join(pairs, ",")
+}
+
+// Set parses a comma separated list of color=count pairs such as red=12,green=13,blue=14. Colors
+// that are not listed are taken to be absent.
+func (s *set) Set(in string) error {
+	parsed := set{}
+	for _, pair := range strings.Split(in, ",") {
+		color, num, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return fmt.Errorf("invalid cube count %q, expected color=count", pair)
+		}
+
+		n, err := strconv.Atoi(strings.TrimSpace(num))
+		if err != nil {
+			return fmt.Errorf("converting %s count %q to int: %w", color, num, err)
+		}
+
+		parsed[strings.TrimSpace(color)] = n
+	}
+
+	*s = parsed
+
+	return nil
+}
+
+// palette lists the cube colors a game record may contain.
+type palette []string
+
+func (p *palette) String() string {
+	return strings.Join(*p, ",")
+}
+
+// Set parses a comma separated list of colors.
+func (p *palette) Set(in string) error {
+	var parsed palette
+	for _, c := range strings.Split(in, ",") {
+		c = strings.TrimSpace(c)
+		if c == "" {
+			return fmt.Errorf("empty color in %q", in)
+		}
+
+		parsed = append(parsed, c)
+	}
+
+	*p = parsed
+
+	return nil
+}
+
+func (p palette) contains(color string) bool {
+	for _, c := range p {
+		if c == color {
+			return true
+		}
+	}
+
+	return false
+}
+
+// record is what parsing a single line produced, held until every line before it is handled.
+type record struct {
+	game     *game
+	err      error
+	warnings []error
+	// empty is set for empty lines, which hold no game record and so take up no game id
+	empty bool
+}
+
+// parseGames streams game records from r, one per line, handing each game parsed to fn in the order
+// they were recorded. Lines are parsed by the parser's workers and every problem found is returned,
+// in line order, once the input has been read.
+func (p *parser) parseGames(r io.Reader, fn func(*game)) error {
+	var errs []error
+
+	var lines, prevID int
+	err := aoc.MapLines(r, p.workers, func(line int, text string) record {
+		// every line gets a parser of its own so workers never share warnings
+		lp := &parser{strict: p.strict, palette: p.palette}
+		game, err := lp.parseGame(text)
+
+		return record{game: game, err: aoc.AtLine(err, line), warnings: lp.warnings, empty: text == ""}
+	}, func(line int, rec record) {
+		lines = line
+		for _, w := range rec.warnings {
+			p.warnings = append(p.warnings, aoc.AtLine(w, line))
+		}
+
+		if rec.err != nil {
+			errs = append(errs, rec.err)
+			// a record that cannot be parsed is taken to hold the id expected of it, so the records
+			// after it are not reported out of sequence as well
+			if !rec.empty {
+				prevID++
+			}
+			return
+		}
+		if rec.game == nil {
+			return
+		}
+
+		expectedID := prevID + 1
+		prevID = rec.game.id
+		if rec.game.id != expectedID && !p.questionable(&errs, &aoc.ParseError{
+			Line:   line,
+			Column: 1,
+			Token:  strconv.Itoa(rec.game.id),
+			Err:    fmt.Errorf("game id %d out of sequence, expected %d", rec.game.id, expectedID),
+		}) {
+			return
+		}
+
+		fn(rec.game)
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if lines == 0 && len(errs) == 0 {
+		return fmt.Errorf("no input received")
+	}
+
+	return errors.Join(errs...)
+}
+
+// parseGame parses a single game record of the form "Game <id>: <set>; <set>...". Problems found
+// with the record are returned as ParseErrors located by column.
+func (p *parser) parseGame(in string) (*game, error) {
+	if in == "" {
+		var errs []error
+		p.questionable(&errs, &aoc.ParseError{Err: fmt.Errorf("empty line")})
+		return nil, errors.Join(errs...)
+	}
+
+	title, sets, ok := strings.Cut(in, ":")
+	if !ok {
+		return nil, &aoc.ParseError{
+			Column: 1,
+			Token:  in,
+			Err:    fmt.Errorf("no ':' found after game title"),
+		}
+	}
+
+	g := &game{}
+
+	id, err := gameID(title)
+	if err != nil {
+		return nil, &aoc.ParseError{
+			Column: 1,
+			Token:  title,
+			Err:    fmt.Errorf("retrieving game id: %w", err),
+		}
+	}
+	g.id = id
+
+	g.sets, err = p.parseSets(sets, len(title)+2)
+	if err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+func gameID(in string) (int, error) {
+	if in == "" {
+		return 0, nil
+	}
+
+	sp := strings.Split(strings.ToLower(in), "game")
+	if len(sp) < 2 {
+		return 0, fmt.Errorf("invalid game title found: %q", in)
+	}
+
+	id, err := strconv.Atoi(strings.TrimSpace(sp[1]))
+	if err != nil {
+		return 0, fmt.Errorf("converting game id %q to int: %w", sp[1], err)
+	}
+
+	return id, nil
+}
+
+// parseSets parses the semicolon separated sets of a game, where col is the column of the line at
+// which in starts.
+func (p *parser) parseSets(in string, col int) ([]set, error) {
+	var sets []set
+	var errs []error
+
+	for _, s := range strings.Split(in, ";") {
+		set, err := p.parseSet(s, col)
+		col += len(s) + 1
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if set == nil {
+			continue
+		}
+
+		sets = append(sets, set)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return sets, nil
+}
+
+// parseSet parses a comma separated list of cube counts such as "3 blue, 4 red", where col is the
+// column of the line at which in starts. Empty entries, such as those left by a trailing comma,
+// colors given without a count, colors outside of the parser's palette and sets without any cubes
+// are questionable and skipped unless strict. Repeated colors keep the last count seen.
+func (p *parser) parseSet(in string, col int) (set, error) {
+	var errs []error
+	start := col
+
+	if strings.TrimSpace(in) == "" {
+		p.questionable(&errs, &aoc.ParseError{Column: start, Err: fmt.Errorf("empty set")})
+		return nil, errors.Join(errs...)
+	}
+
+	s := set{}
+	seen := map[string]bool{}
+
+	for _, c := range strings.Split(in, ",") {
+		entry := strings.TrimSpace(c)
+		entryCol := col + strings.Index(c, entry)
+		col += len(c) + 1
+
+		if entry == "" {
+			p.questionable(&errs, &aoc.ParseError{Column: entryCol, Err: fmt.Errorf("missing cube count")})
+			continue
+		}
+
+		num, color, ok := strings.Cut(entry, " ")
+		if !ok && !strings.ContainsAny(entry, "0123456789") {
+			// a color on its own, its count left out
+			p.questionable(&errs, &aoc.ParseError{
+				Column: entryCol,
+				Token:  entry,
+				Err:    fmt.Errorf("missing cube count for %q", entry),
+			})
+			continue
+		}
+		if !ok {
+			errs = append(errs, &aoc.ParseError{
+				Column: entryCol,
+				Token:  entry,
+				Err:    fmt.Errorf("invalid cube count %q, expected <count> <color>", entry),
+			})
+			continue
+		}
+
+		n, err := strconv.Atoi(num)
+		if err != nil {
+			errs = append(errs, &aoc.ParseError{
+				Column: entryCol,
+				Token:  num,
+				Err:    fmt.Errorf("converting cube count %q to int: %w", num, err),
+			})
+			continue
+		}
+
+		if n < 0 && !p.questionable(&errs, &aoc.ParseError{
+			Column: entryCol,
+			Token:  num,
+			Err:    fmt.Errorf("negative cube count %d", n),
+		}) {
+			continue
+		}
+
+		color = strings.TrimSpace(color)
+		if seen[color] && !p.questionable(&errs, &aoc.ParseError{
+			Column: entryCol,
+			Token:  color,
+			Err:    fmt.Errorf("color %q repeated within set", color),
+		}) {
+			continue
+		}
+		seen[color] = true
+
+		if !p.palette.contains(color) {
+			p.questionable(&errs, &aoc.ParseError{
+				Column: entryCol,
+				Token:  color,
+				Err:    fmt.Errorf("unknown color %q", color),
+			})
+			continue
+		}
+
+		s[color] = n
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	if s.empty() {
+		p.questionable(&errs, &aoc.ParseError{Column: start, Err: fmt.Errorf("set has no cubes")})
+		return nil, errors.Join(errs...)
+	}
+
+	return s, nil
+}
+
+// empty reports whether the set holds no cubes at all.
+func (s set) empty() bool {
+	for _, n := range s {
+		if n != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// possibleGame reports whether every set of the game could have been drawn from a bag holding
+// totals. Colors missing from totals are not in the bag at all.
+func possibleGame(totals set, check *game) bool {
+	if totals.empty() {
+		return false
+	}
+
+	for _, s := range check.sets {
+		for color, n := range s {
+			if n > totals[color] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// minimumGamePower multiplies together the fewest cubes of each color seen in the game that could
+// have made it possible.
+func minimumGamePower(g *game) int {
+	minimum := set{}
+	for _, s := range g.sets {
+		for color, n := range s {
+			if cur, ok := minimum[color]; !ok || n > cur {
+				minimum[color] = n
+			}
+		}
+	}
+
+	if len(minimum) == 0 {
+		return 0
+	}
+
+	power := 1
+	for _, n := range minimum {
+		power *= n
+	}
+
+	return power
+}
@@ -0,0 +1,732 @@
+package day02
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mxygem/advent-of-code-2023/aoc"
+)
+
+func TestSolver(t *testing.T) {
+	testCases := []struct {
+		name          string
+		gamesIn       string
+		expectedPart1 aoc.Answer
+		expectedPart2 aoc.Answer
+		expectedErr   error
+	}{
+		{
+			name:        "no input",
+			gamesIn:     "",
+			expectedErr: fmt.Errorf("no input received"),
+		},
+		{
+			name:          "two games",
+			gamesIn:       "Game 1: 1 red, 1 blue, 1 green; 11 green, 11 blue, 11 red\nGame 2: 2 red, 2 blue, 2 green",
+			expectedPart1: 3,
+			expectedPart2: 1339,
+		},
+		{
+			name:          "one game not possible",
+			gamesIn:       "Game 3: 1 red, 1 blue, 1 green\nGame 4: 14 red, 2 blue, 2 green",
+			expectedPart1: 3,
+			expectedPart2: 57,
+		},
+		{
+			name:          "sets are checked individually, not summed",
+			gamesIn:       "Game 5: 1 red, 1 blue, 1 green\nGame 10: 3 red, 7 blue, 2 green; 4 red, 8 blue, 3 green\nGame 15: 3 red, 3 blue, 3 green",
+			expectedPart1: 30,
+			expectedPart2: 124,
+		},
+		{
+			name: "example",
+			gamesIn: `Game 1: 3 blue, 4 red; 1 red, 2 green, 6 blue; 2 green
+Game 2: 1 blue, 2 green; 3 green, 4 blue, 1 red; 1 green, 1 blue
+Game 3: 8 green, 6 blue, 20 red; 5 blue, 4 red, 13 green; 5 green, 1 red
+Game 4: 1 green, 3 red, 6 blue; 3 green, 6 red; 3 green, 15 blue, 14 red
+Game 5: 6 red, 1 blue, 3 green; 2 blue, 1 red, 2 green`,
+			expectedPart1: 8,
+			expectedPart2: 2286,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			part1, err := newSolver().Part1(strings.NewReader(tc.gamesIn))
+			assert.Equal(t, tc.expectedPart1, part1)
+			checkErr(t, tc.expectedErr, err)
+
+			part2, err := newSolver().Part2(strings.NewReader(tc.gamesIn))
+			assert.Equal(t, tc.expectedPart2, part2)
+			checkErr(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestSolverBag(t *testing.T) {
+	testCases := []struct {
+		name        string
+		bag         string
+		expected    aoc.Answer
+		expectedErr error
+	}{
+		{
+			name:     "puzzle bag",
+			bag:      "red=12,green=13,blue=14",
+			expected: 8,
+		},
+		{
+			name:     "enough red for game 3",
+			bag:      "red=20, green=13, blue=14",
+			expected: 11,
+		},
+		{
+			name:     "missing colors are empty",
+			bag:      "red=20,blue=20",
+			expected: 0,
+		},
+		{
+			name:        "colors outside the palette",
+			bag:         "red=20,green=13,blue=14,yellow=2",
+			expectedErr: fmt.Errorf("bag holds yellow cubes, which are not among the colors red,green,blue given by -colors"),
+		},
+	}
+
+	games := `Game 1: 3 blue, 4 red; 1 red, 2 green, 6 blue; 2 green
+Game 2: 1 blue, 2 green; 3 green, 4 blue, 1 red; 1 green, 1 blue
+Game 3: 8 green, 6 blue, 20 red; 5 blue, 4 red, 13 green; 5 green, 1 red
+Game 4: 1 green, 3 red, 6 blue; 3 green, 6 red; 3 green, 15 blue, 14 red
+Game 5: 6 red, 1 blue, 3 green; 2 blue, 1 red, 2 green`
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := configuredSolver(t, "-bag", tc.bag).Part1(strings.NewReader(games))
+			checkErr(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestBagFlagValue(t *testing.T) {
+	testCases := []struct {
+		name        string
+		in          string
+		expected    set
+		expectedErr error
+	}{
+		{
+			name:     "pairs",
+			in:       "red=20, green=13",
+			expected: set{"red": 20, "green": 13},
+		},
+		{
+			name:        "not a pair",
+			in:          "red",
+			expectedErr: fmt.Errorf(`invalid cube count "red", expected color=count`),
+		},
+		{
+			name:        "count not a number",
+			in:          "red=many",
+			expectedErr: fmt.Errorf(`converting red count "many" to int: strconv.Atoi: parsing "many": invalid syntax`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var actual set
+			err := actual.Set(tc.in)
+			checkErr(t, tc.expectedErr, err)
+			if tc.expectedErr == nil {
+				assert.Equal(t, tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSolverColors(t *testing.T) {
+	games := `Game 1: 3 blue, 4 yellow; 2 purple
+Game 2: 1 blue, 2 purple; 5 yellow
+Game 3: 1 red, 1 blue`
+
+	s := configuredSolver(t, "-colors", "red,blue,yellow,purple", "-bag", "blue=3,yellow=4,purple=2")
+
+	part1, err := s.Part1(strings.NewReader(games))
+	require.NoError(t, err)
+	assert.Equal(t, aoc.Answer(1), part1)
+
+	part2, err := s.Part2(strings.NewReader(games))
+	require.NoError(t, err)
+	assert.Equal(t, aoc.Answer(3*4*2+1*5*2+1*1), part2)
+	assert.Empty(t, s.Warnings())
+
+	s = newSolver()
+	_, err = s.Part2(strings.NewReader(games))
+	require.NoError(t, err)
+	assert.Len(t, s.Warnings(), 6, "yellow and purple are outside the default palette")
+}
+
+func TestMinimumGamePower(t *testing.T) {
+	testCases := []struct {
+		name     string
+		game     *game
+		expected int
+	}{
+		{
+			name:     "no sets",
+			game:     &game{},
+			expected: 0,
+		},
+		{
+			name:     "only observed colors count",
+			game:     &game{sets: []set{{"red": 2}, {"blue": 3}}},
+			expected: 6,
+		},
+		{
+			name:     "observed zero",
+			game:     &game{sets: []set{{"red": 2, "green": 0}, {"blue": 3}}},
+			expected: 0,
+		},
+		{
+			name:     "largest count of each color",
+			game:     &game{sets: []set{{"red": 2, "yellow": 7}, {"red": 4, "yellow": 1}}},
+			expected: 28,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, minimumGamePower(tc.game))
+		})
+	}
+}
+
+func TestParseGames(t *testing.T) {
+	testCases := []struct {
+		name        string
+		gamesIn     string
+		expected    []*game
+		expectedErr error
+	}{
+		{
+			name:        "no input",
+			gamesIn:     "",
+			expectedErr: fmt.Errorf("no input received"),
+		},
+		{
+			name:    "errors are located by line",
+			gamesIn: "Game 1: 1 red\nGame 2: 2 blue, 1\nGame 3: 3 green\nGame: 4 red",
+			expectedErr: fmt.Errorf(`line 2, column 17: invalid cube count "1", expected <count> <color>
+line 4, column 1: retrieving game id: converting game id "" to int: strconv.Atoi: parsing "": invalid syntax`),
+		},
+		{
+			name:    "empty lines are skipped",
+			gamesIn: "Game 1: 1 red\n\nGame 2: 2 blue",
+			expected: []*game{
+				{id: 1, sets: []set{{"red": 1}}},
+				{id: 2, sets: []set{{"blue": 2}}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := parseAll(&parser{palette: puzzlePalette}, tc.gamesIn)
+
+			assert.Equal(t, tc.expected, actual)
+			checkErr(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestParseGamesSequenceAfterFailure(t *testing.T) {
+	in := "Game 1: 1 red\nGame 2: x red\nGame 3: 3 green"
+
+	for _, strict := range []bool{false, true} {
+		t.Run(fmt.Sprintf("strict %t", strict), func(t *testing.T) {
+			p := &parser{strict: strict, palette: puzzlePalette}
+			var ids []int
+			err := p.parseGames(strings.NewReader(in), func(g *game) { ids = append(ids, g.id) })
+
+			checkErr(t, fmt.Errorf(`line 2, column 9: converting cube count "x" to int: strconv.Atoi: parsing "x": invalid syntax`), err)
+			assert.Empty(t, p.warnings, "the game after a failed record is in sequence")
+			assert.Equal(t, []int{1, 3}, ids)
+		})
+	}
+}
+
+func TestParseGamesReadFailure(t *testing.T) {
+	in := io.MultiReader(strings.NewReader("Game 1: 1 red\nGame 2: 2 blue\n"), iotest.ErrReader(errors.New("disk on fire")))
+
+	var ids []int
+	err := (&parser{palette: puzzlePalette}).parseGames(in, func(g *game) { ids = append(ids, g.id) })
+
+	var pe *aoc.ParseError
+	require.ErrorAs(t, err, &pe)
+	assert.Equal(t, "line 3: reading line: disk on fire", err.Error())
+	assert.Equal(t, []int{1, 2}, ids, "games read before the failure are still handed over")
+}
+
+// TestParseGamesWorkers checks that spreading records across workers hands over the same games,
+// warnings and errors, in the same order, as parsing them in turn.
+func TestParseGamesWorkers(t *testing.T) {
+	var b strings.Builder
+	for id := 1; id <= 2000; id++ {
+		switch {
+		case id%97 == 0:
+			fmt.Fprintf(&b, "Game %d: 1 red, 2\n", id)
+		case id%31 == 0:
+			fmt.Fprintf(&b, "Game %d: 1 red, %d yellow\n", id, id)
+		default:
+			fmt.Fprintf(&b, "Game %d: %d red; %d blue, 1 green\n", id, id%13, id%7)
+		}
+	}
+	in := b.String()
+
+	for _, strict := range []bool{false, true} {
+		t.Run(fmt.Sprintf("strict %t", strict), func(t *testing.T) {
+			sequential := &parser{strict: strict, palette: puzzlePalette}
+			var expectedGames []*game
+			expectedErr := sequential.parseGames(strings.NewReader(in), func(g *game) { expectedGames = append(expectedGames, g) })
+			require.Error(t, expectedErr)
+
+			for _, workers := range []int{2, 8} {
+				concurrent := &parser{strict: strict, palette: puzzlePalette, workers: workers}
+				var actualGames []*game
+				actualErr := concurrent.parseGames(strings.NewReader(in), func(g *game) { actualGames = append(actualGames, g) })
+
+				assert.Equal(t, expectedErr.Error(), actualErr.Error())
+				assert.Equal(t, fmt.Sprint(sequential.warnings), fmt.Sprint(concurrent.warnings))
+				assert.Equal(t, expectedGames, actualGames)
+			}
+		})
+	}
+}
+
+func TestParserModes(t *testing.T) {
+	testCases := []struct {
+		name            string
+		gamesIn         string
+		expectedGames   []*game
+		expectedWarning string
+	}{
+		{
+			name:            "unknown color",
+			gamesIn:         "Game 1: 1 red, 2 yellow",
+			expectedGames:   []*game{{id: 1, sets: []set{{"red": 1}}}},
+			expectedWarning: `line 1, column 16: unknown color "yellow"`,
+		},
+		{
+			name:            "repeated color",
+			gamesIn:         "Game 1: 3 blue, 4 blue",
+			expectedGames:   []*game{{id: 1, sets: []set{{"blue": 4}}}},
+			expectedWarning: `line 1, column 17: color "blue" repeated within set`,
+		},
+		{
+			name:            "negative count",
+			gamesIn:         "Game 1: -1 green, 2 red",
+			expectedGames:   []*game{{id: 1, sets: []set{{"green": -1, "red": 2}}}},
+			expectedWarning: `line 1, column 9: negative cube count -1`,
+		},
+		{
+			name:            "missing count",
+			gamesIn:         "Game 1: 1 red,",
+			expectedGames:   []*game{{id: 1, sets: []set{{"red": 1}}}},
+			expectedWarning: `line 1, column 15: missing cube count`,
+		},
+		{
+			name:            "color without a count",
+			gamesIn:         "Game 1: blue, 3 red",
+			expectedGames:   []*game{{id: 1, sets: []set{{"red": 3}}}},
+			expectedWarning: `line 1, column 9: missing cube count for "blue"`,
+		},
+		{
+			name:            "empty set",
+			gamesIn:         "Game 1: 1 red;; 2 blue",
+			expectedGames:   []*game{{id: 1, sets: []set{{"red": 1}, {"blue": 2}}}},
+			expectedWarning: `line 1, column 15: empty set`,
+		},
+		{
+			name:            "set without cubes",
+			gamesIn:         "Game 1: 0 red",
+			expectedGames:   []*game{{id: 1}},
+			expectedWarning: `line 1, column 8: set has no cubes`,
+		},
+		{
+			name:            "empty line",
+			gamesIn:         "Game 1: 1 red\n\nGame 2: 1 red",
+			expectedGames:   []*game{{id: 1, sets: []set{{"red": 1}}}, {id: 2, sets: []set{{"red": 1}}}},
+			expectedWarning: `line 2: empty line`,
+		},
+		{
+			name:            "game ids out of sequence",
+			gamesIn:         "Game 1: 1 red\nGame 3: 1 red\nGame 4: 1 red",
+			expectedGames:   []*game{{id: 1, sets: []set{{"red": 1}}}, {id: 3, sets: []set{{"red": 1}}}, {id: 4, sets: []set{{"red": 1}}}},
+			expectedWarning: `line 2, column 1: game id 3 out of sequence, expected 2`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			lenient := &parser{palette: puzzlePalette}
+			actual, err := parseAll(lenient, tc.gamesIn)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedGames, actual)
+			require.Len(t, lenient.warnings, 1)
+			assert.Equal(t, tc.expectedWarning, lenient.warnings[0].Error())
+
+			strict := &parser{strict: true, palette: puzzlePalette}
+			actual, err = parseAll(strict, tc.gamesIn)
+
+			assert.Nil(t, actual)
+			checkErr(t, errors.New(tc.expectedWarning), err)
+			assert.Empty(t, strict.warnings)
+		})
+	}
+}
+
+func TestParseGame(t *testing.T) {
+	testCases := []struct {
+		name        string
+		gameIn      string
+		expected    *game
+		expectedErr error
+	}{
+		{
+			name:     "no input",
+			gameIn:   "",
+			expected: nil,
+		},
+		{
+			name:     "no sets found",
+			gameIn:   "Game 1:",
+			expected: &game{id: 1},
+		},
+		{
+			name:     "single set",
+			gameIn:   "Game 2: 1 red, 2 blue, 3 green",
+			expected: &game{id: 2, sets: []set{{"red": 1, "blue": 2, "green": 3}}},
+		},
+		{
+			name:   "three sets - all colors in order",
+			gameIn: "Game 3: 1 red, 2 blue, 3 green; 4 red, 5 blue, 6 green; 7 red, 8 blue, 9 green",
+			expected: &game{id: 3, sets: []set{
+				{"red": 1, "blue": 2, "green": 3},
+				{"red": 4, "blue": 5, "green": 6},
+				{"red": 7, "blue": 8, "green": 9},
+			}},
+		},
+		{
+			name:   "trailing comma after second set",
+			gameIn: "Game 4: 8 blue, 9 red, 7 green; 5 blue, 4 green, 6 red,; 1 green, 2 blue, 3 red",
+			expected: &game{id: 4, sets: []set{
+				{"red": 9, "blue": 8, "green": 7},
+				{"red": 6, "blue": 5, "green": 4},
+				{"red": 3, "blue": 2, "green": 1},
+			}},
+		},
+		{
+			name:   "five sets - single color in each",
+			gameIn: "Game 5: 1 green; 2 blue; 3 red; 4 red; 6 blue",
+			expected: &game{id: 5, sets: []set{
+				{"green": 1},
+				{"blue": 2},
+				{"red": 3},
+				{"red": 4},
+				{"blue": 6},
+			}},
+		},
+		{
+			name:   "empty set within multiples",
+			gameIn: "Game 6: 3 red, 3 blue, 3 green;; 4 red, 4 blue, 4 green",
+			expected: &game{id: 6, sets: []set{
+				{"red": 3, "blue": 3, "green": 3},
+				{"red": 4, "blue": 4, "green": 4},
+			}},
+		},
+		{
+			name:   "no space in game name",
+			gameIn: "Game7: 5 red, 5 blue, 5 green",
+			expected: &game{id: 7, sets: []set{
+				{"red": 5, "blue": 5, "green": 5},
+			}},
+		},
+		{
+			name:   "no spaces in sets",
+			gameIn: "Game 8:1red,1blue,1green;2red,2blue,2green",
+			expectedErr: fmt.Errorf(`column 8: invalid cube count "1red", expected <count> <color>
+column 13: invalid cube count "1blue", expected <count> <color>
+column 19: invalid cube count "1green", expected <count> <color>
+column 26: invalid cube count "2red", expected <count> <color>
+column 31: invalid cube count "2blue", expected <count> <color>
+column 37: invalid cube count "2green", expected <count> <color>`),
+		},
+		{
+			name:        "no colon after title",
+			gameIn:      "Game 9 1 red",
+			expectedErr: fmt.Errorf(`column 1: no ':' found after game title`),
+		},
+		{
+			name:        "invalid id",
+			gameIn:      "Game X: 1 red",
+			expectedErr: fmt.Errorf(`column 1: retrieving game id: converting game id " x" to int: strconv.Atoi: parsing "x": invalid syntax`),
+		},
+		{
+			name:        "count not a number",
+			gameIn:      "Game 10: 1 red; 2 blue, two green",
+			expectedErr: fmt.Errorf(`column 25: converting cube count "two" to int: strconv.Atoi: parsing "two": invalid syntax`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := (&parser{palette: puzzlePalette}).parseGame(tc.gameIn)
+
+			assert.Equal(t, tc.expected, actual)
+			checkErr(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestGameID(t *testing.T) {
+	testCases := []struct {
+		name        string
+		headerIn    string
+		expected    int
+		expectedErr error
+	}{
+		{
+			name:     "empty",
+			headerIn: "",
+			expected: 0,
+		},
+		{
+			name:     "expected formatting",
+			headerIn: "Game 1",
+			expected: 1,
+		},
+		{
+			name:     "no space",
+			headerIn: "Game2",
+			expected: 2,
+		},
+		{
+			name:        "only id",
+			headerIn:    "2",
+			expectedErr: fmt.Errorf(`invalid game title found: "2"`),
+		},
+		{
+			name:        "id is not number",
+			headerIn:    "Game A",
+			expectedErr: fmt.Errorf(`converting game id " a" to int: strconv.Atoi: parsing "a": invalid syntax`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := gameID(tc.headerIn)
+
+			assert.Equal(t, tc.expected, actual)
+			checkErr(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestParseSets(t *testing.T) {
+	testCases := []struct {
+		name     string
+		setsIn   string
+		expected []set
+	}{
+		{
+			name:   "single set",
+			setsIn: " 1 red, 2 blue, 3 green",
+			expected: []set{
+				{"red": 1, "blue": 2, "green": 3},
+			},
+		},
+		{
+			name:   "multiple sets in order",
+			setsIn: " 1 red, 2 blue, 3 green; 4 red, 5 blue, 6 green; 7 red, 8 blue, 9 green",
+			expected: []set{
+				{"red": 1, "blue": 2, "green": 3},
+				{"red": 4, "blue": 5, "green": 6},
+				{"red": 7, "blue": 8, "green": 9},
+			},
+		},
+		{
+			name:   "trailing comma after second set",
+			setsIn: " 8 blue, 9 red, 7 green; 5 blue, 4 green, 6 red,; 1 green, 2 blue, 3 red",
+			expected: []set{
+				{"red": 9, "blue": 8, "green": 7},
+				{"red": 6, "blue": 5, "green": 4},
+				{"red": 3, "blue": 2, "green": 1},
+			},
+		},
+		{
+			name:   "empty sets are not returned",
+			setsIn: "1 red;; 1 blue;; 1 green, 2 blue, 3 red",
+			expected: []set{
+				{"red": 1},
+				{"blue": 1},
+				{"red": 3, "blue": 2, "green": 1},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := (&parser{palette: puzzlePalette}).parseSets(tc.setsIn, 1)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestParseSet(t *testing.T) {
+	testCases := []struct {
+		name        string
+		setIn       string
+		expected    set
+		expectedErr error
+	}{
+		{
+			name:     "empty",
+			setIn:    "",
+			expected: nil,
+		},
+		{
+			name:     "expected formatting and in order",
+			setIn:    " 1 red, 2 blue, 3 green",
+			expected: set{"red": 1, "blue": 2, "green": 3},
+		},
+		{
+			name:     "expected formatting and in order",
+			setIn:    "4 blue, 5 green, 6 red",
+			expected: set{"red": 6, "blue": 4, "green": 5},
+		},
+		{
+			name:     "trailing comma",
+			setIn:    " 4 blue,",
+			expected: set{"blue": 4},
+		},
+		{
+			name:        "missing color",
+			setIn:       " 1 red,  7",
+			expectedErr: fmt.Errorf(`column 10: invalid cube count "7", expected <count> <color>`),
+		},
+		{
+			name:  "every problem is reported",
+			setIn: " x red, 2 blue, y green",
+			expectedErr: fmt.Errorf(`column 2: converting cube count "x" to int: strconv.Atoi: parsing "x": invalid syntax
+column 17: converting cube count "y" to int: strconv.Atoi: parsing "y": invalid syntax`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := (&parser{palette: puzzlePalette}).parseSet(tc.setIn, 1)
+
+			assert.Equal(t, tc.expected, actual)
+			checkErr(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestPossibleGame(t *testing.T) {
+	testCases := []struct {
+		name     string
+		totals   set
+		check    *game
+		expected bool
+	}{
+		{
+			name:     "totals zero",
+			totals:   set{},
+			expected: false,
+		},
+		{
+			name:     "red too high for only set",
+			totals:   set{"red": 10},
+			check:    &game{sets: []set{{"red": 20}}},
+			expected: false,
+		},
+		{
+			name:     "blue too high for last set",
+			totals:   set{"blue": 10},
+			check:    &game{sets: []set{{"blue": 5}, {"blue": 10}, {"blue": 20}}},
+			expected: false,
+		},
+		{
+			name:     "green is checked per set, not summed across sets",
+			totals:   set{"green": 20},
+			check:    &game{sets: []set{{"green": 5}, {"green": 10}, {"green": 10}}},
+			expected: true,
+		},
+		{
+			name:     "color missing from bag",
+			totals:   set{"red": 10},
+			check:    &game{sets: []set{{"red": 2, "yellow": 1}}},
+			expected: false,
+		},
+		{
+			name:   "all values valid",
+			totals: set{"red": 15, "blue": 15, "green": 15},
+			check: &game{sets: []set{
+				{"red": 2, "blue": 2, "green": 2},
+				{"red": 3, "blue": 3, "green": 3},
+				{"red": 4, "blue": 4, "green": 4},
+			}},
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, possibleGame(tc.totals, tc.check))
+		})
+	}
+}
+
+// parseAll collects every game p parses from in, or none if parsing fails.
+func parseAll(p *parser, in string) ([]*game, error) {
+	var gs []*game
+	if err := p.parseGames(strings.NewReader(in), func(g *game) { gs = append(gs, g) }); err != nil {
+		return nil, err
+	}
+
+	return gs, nil
+}
+
+// configuredSolver returns a new solver with its flags parsed from args.
+func configuredSolver(t *testing.T, args ...string) *solver {
+	t.Helper()
+
+	s := newSolver()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	s.Flags(fs)
+	require.NoError(t, fs.Parse(args))
+
+	return s
+}
+
+// puzzlePalette holds the colors of the puzzle's own cubes.
+var puzzlePalette = palette{"red", "green", "blue"}
+
+func checkErr(t *testing.T, expected, actual error) {
+	if expected != nil {
+		require.Error(t, actual)
+		assert.Equal(t, expected.Error(), actual.Error())
+	} else {
+		assert.Nil(t, actual)
+	}
+}
@@ -0,0 +1,326 @@
+package day03
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/mxygem/advent-of-code-2023/aoc"
+	"github.com/mxygem/advent-of-code-2023/grid"
+)
+
+func init() {
+	aoc.Register(3, newSolver())
+}
+
+// solver sums every part number in the schematic for part 1 and evaluates the symbols of the rule
+// table for part 2, the table holding only the puzzle's rule for gears unless one is given. The
+// schematic is read in the standard dialect unless a dialect file or any of the dialect's settings
+// are given, settings given on their own taking precedence over the file.
+type solver struct {
+	dialectFile                   string
+	blank, ignored, markers, gear setting
+	rules                         rules
+	printReport                   bool
+	last                          *report
+	// render, crop and picture describe how the most recent schematic, held in drawn, is rendered
+	// and where it is drawn to, if at all
+	render  string
+	crop    crop
+	picture string
+	drawn   *schematic
+}
+
+func newSolver() *solver {
+	return &solver{}
+}
+
+func (s *solver) Flags(fs *flag.FlagSet) {
+	*s = *newSolver()
+
+	fs.StringVar(&s.dialectFile, "dialect", "", "day 3: location of a schematic dialect file of blank, ignore, markers and gear settings")
+	fs.Var(&s.blank, "blank", fmt.Sprintf("day 3: `characters` standing for empty cells of the schematic (default %q)", standard.blank))
+	fs.Var(&s.ignored, "ignore", "day 3: `characters` removed from the schematic before it is read")
+	fs.Var(&s.markers, "markers", "day 3: `symbols` marking the numbers next to them as part numbers, every symbol when empty")
+	fs.Var(&s.gear, "gear", fmt.Sprintf("day 3: `symbol` acting as a gear (default %q)", standard.gear))
+	fs.Var(&s.rules, "rules", fmt.Sprintf("day 3: comma separated symbol=operator[:parts] `rules` evaluated by part 2, operators being any of %s, defaulting to product:2 for the gear symbol", strings.Join(operatorNames(), ", ")))
+	fs.BoolVar(&s.printReport, "report", false, "day 3: print the result of every rule evaluated by part 2")
+	fs.StringVar(&s.render, "render", "", fmt.Sprintf("day 3: print the schematic with parts, other numbers, symbols and gears marked in the given `style`, any of %s", strings.Join(renderStyles, ", ")))
+	fs.Var(&s.crop, "crop", "day 3: `rows:cols` window of the rendered schematic, such as 10-20:30-70")
+	fs.StringVar(&s.picture, "draw", "", fmt.Sprintf("day 3: draw the schematic with its parts boxed and its gears joined to their parts to a `file`, any of %s", strings.Join(pictureFormats, ", ")))
+}
+
+// Report prints the result of every rule evaluated by the most recent part 2, then renders and
+// draws the most recent schematic, if asked to.
+func (s *solver) Report(w io.Writer) error {
+	if s.printReport && s.last != nil {
+		if err := s.last.write(w); err != nil {
+			return err
+		}
+	}
+
+	if s.drawn == nil {
+		return nil
+	}
+
+	if s.render != "" {
+		if err := s.drawn.render(w, s.render, s.crop); err != nil {
+			return err
+		}
+	}
+
+	if s.picture != "" {
+		return s.drawn.save(s.picture)
+	}
+
+	return nil
+}
+
+// keepSchematic reads the whole schematic from r into memory when it is to be rendered or drawn,
+// evaluating it against the rules of part 2 whichever part is solved, and returns a reader of the
+// same schematic for the solver to read instead.
+func (s *solver) keepSchematic(r io.Reader, d *dialect) (io.Reader, error) {
+	s.drawn = nil
+	if s.render == "" && s.picture == "" {
+		return r, nil
+	}
+	if s.render != "" {
+		if err := checkRenderStyle(s.render); err != nil {
+			return nil, err
+		}
+	}
+	if s.picture != "" {
+		if err := checkPictureFormat(s.picture); err != nil {
+			return nil, err
+		}
+	}
+
+	rs, err := s.ruleTable(d)
+	if err != nil {
+		return nil, err
+	}
+
+	in, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading schematic: %w", err)
+	}
+
+	if s.drawn, err = readSchematic(bytes.NewReader(in), d, rs); err != nil {
+		return nil, err
+	}
+
+	return bytes.NewReader(in), nil
+}
+
+// ruleTable returns the rules part 2 evaluates schematics of the given dialect with.
+func (s *solver) ruleTable(d *dialect) (rules, error) {
+	rs := s.rules
+	if len(rs) == 0 {
+		rs = rules{gearRule(d)}
+	}
+	if err := rs.validate(d); err != nil {
+		return nil, err
+	}
+
+	return rs, nil
+}
+
+// dialect builds the dialect the schematic is read in.
+func (s *solver) dialect() (*dialect, error) {
+	d := standard
+	if s.dialectFile != "" {
+		var err error
+		if d, err = loadDialect(s.dialectFile, d); err != nil {
+			return nil, err
+		}
+	}
+
+	s.blank.apply(&d.blank)
+	s.ignored.apply(&d.ignored)
+	s.markers.apply(&d.markers)
+	s.gear.apply(&d.gear)
+
+	if err := d.validate(); err != nil {
+		return nil, fmt.Errorf("invalid schematic dialect: %w", err)
+	}
+
+	return &d, nil
+}
+
+func (s *solver) Part1(r io.Reader) (aoc.Answer, error) {
+	d, err := s.dialect()
+	if err != nil {
+		return 0, err
+	}
+
+	if r, err = s.keepSchematic(r, d); err != nil {
+		return 0, err
+	}
+
+	sum, err := partNumberSum(r, d)
+	return aoc.Answer(sum), err
+}
+
+func (s *solver) Part2(r io.Reader) (aoc.Answer, error) {
+	d, err := s.dialect()
+	if err != nil {
+		return 0, err
+	}
+
+	rs, err := s.ruleTable(d)
+	if err != nil {
+		return 0, err
+	}
+
+	if r, err = s.keepSchematic(r, d); err != nil {
+		return 0, err
+	}
+
+	rep, err := evaluate(r, d, rs)
+	if err != nil {
+		return 0, err
+	}
+	s.last = rep
+
+	return aoc.Answer(rep.total), nil
+}
+
+// partNumberSum adds together every number in the schematic adjacent to a part marker.
+func partNumberSum(r io.Reader, d *dialect) (int, error) {
+	var sum int
+	err := scanParts(r, d, func(p *part) {
+		sum += p.val
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return sum, nil
+}
+
+// scanParts streams the schematic from r, handing every part number to fn in reading order as soon
+// as the rows either side of it have been read. Only those three rows are held at a time. Numbers
+// that cannot be read are reported as ParseErrors located by line and column once the whole
+// schematic has been read.
+func scanParts(r io.Reader, d *dialect, fn func(*part)) error {
+	var errs []error
+
+	// window holds the most recent rows of the schematic, the first of them being row first
+	var window []string
+	var first int
+	emit := func(row int) {
+		ps, err := rowParts(grid.New(window), d, row)
+		if err != nil {
+			errs = append(errs, aoc.AtLine(err, first+row+1))
+		}
+
+		for _, p := range ps {
+			p.Row += first
+			for _, sym := range p.symbols {
+				sym.Row += first
+			}
+			fn(p)
+		}
+	}
+
+	err := aoc.ScanLines(r, func(_ int, text string) {
+		window = append(window, strings.TrimSpace(d.strip(text)))
+
+		switch len(window) {
+		case 2:
+			emit(0)
+		case 3:
+			emit(1)
+			window = window[1:]
+			first++
+		}
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if err == nil && len(window) > 0 {
+		emit(len(window) - 1)
+	}
+
+	return errors.Join(errs...)
+}
+
+// part is a number next to at least one part marker. symbols holds every marker around it in
+// reading order.
+type part struct {
+	val int
+	grid.Span
+	symbols []*symbol
+}
+
+type symbol struct {
+	kind string
+	grid.Point
+}
+
+// rowParts returns every number in the given row of g adjacent to a part marker. Numbers that cannot be
+// read are reported as ParseErrors located by column.
+func rowParts(g *grid.Grid, d *dialect, row int) ([]*part, error) {
+	var posParts []*part
+	var errs []error
+	for _, span := range g.Spans(row, isDigit) {
+		token := string(g.Row(row)[span.Start : span.End+1])
+		num, err := strconv.Atoi(token)
+		if err != nil {
+			errs = append(errs, &aoc.ParseError{
+				Column: span.Start + 1,
+				Token:  token,
+				Err:    fmt.Errorf("converting part number %q to int: %w", token, err),
+			})
+			continue
+		}
+
+		p, ok := isPartNumber(g, d, &part{val: num, Span: span})
+		if !ok {
+			continue
+		}
+
+		posParts = append(posParts, p)
+	}
+
+	return posParts, errors.Join(errs...)
+}
+
+// isDigit reports whether c is one of the ASCII digits part numbers are written with. Other runes,
+// multibyte ones included, are symbols unless the dialect says otherwise.
+func isDigit(c rune) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isPartNumber returns a copy of possPart along with every part marker found around it, if there are
+// any.
+func isPartNumber(g *grid.Grid, d *dialect, possPart *part) (*part, bool) {
+	if possPart == nil {
+		return nil, false
+	}
+	if !g.InBounds(grid.Point{Row: possPart.Row, Col: possPart.Start}) || !g.InBounds(grid.Point{Row: possPart.Row, Col: possPart.End}) {
+		return nil, false
+	}
+
+	pp := *possPart
+
+	for _, p := range g.Around(pp.Span) {
+		c, _ := g.At(p)
+		if !d.isMarker(c) {
+			continue
+		}
+
+		pp.symbols = append(pp.symbols, &symbol{kind: string(c), Point: p})
+	}
+
+	if len(pp.symbols) == 0 {
+		return nil, false
+	}
+
+	return &pp, true
+}
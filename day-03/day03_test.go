@@ -0,0 +1,757 @@
+package day03
+
+import (
+	"flag"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mxygem/advent-of-code-2023/aoc"
+	"github.com/mxygem/advent-of-code-2023/grid"
+)
+
+func TestSolver(t *testing.T) {
+	testCases := []struct {
+		name          string
+		input         string
+		expectedPart1 aoc.Answer
+		expectedPart2 aoc.Answer
+	}{
+		{
+			name: "no part numbers",
+			input: `....#..987
+			123.#.....
+			....#.3...`,
+		},
+		{
+			name: "single gear",
+			input: `....10....
+			....*10...`,
+			expectedPart1: 20,
+			expectedPart2: 100,
+		},
+		{
+			name: "example",
+			input: `467..114..
+			...*......
+			..35..633.
+			......#...
+			617*......
+			.....+.58.
+			..592.....
+			......755.
+			...$.*....
+			.664.598..`,
+			expectedPart1: 4361,
+			expectedPart2: 467835,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			part1, err := newSolver().Part1(strings.NewReader(tc.input))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedPart1, part1)
+
+			part2, err := newSolver().Part2(strings.NewReader(tc.input))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedPart2, part2)
+		})
+	}
+}
+
+func TestPartNumberSum(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected int
+	}{
+		{
+			name: "no part numbers",
+			input: `....#..987
+			123.#.....
+			....#.3...`,
+			expected: 0,
+		},
+		{
+			name: "four part numbers sharing symbols",
+			input: `....10....
+			..10##10..
+			....10....`,
+			expected: 40,
+		},
+		{
+			name: "example",
+			input: `467..114..
+			...*......
+			..35..633.
+			......#...
+			617*......
+			.....+.58.
+			..592.....
+			......755.
+			...$.*....
+			.664.598..`,
+			expected: 4361,
+		},
+		{
+			name: "gear members are counted once",
+			input: `...10...99
+			        .10*10..*1`,
+			expected: 130,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := partNumberSum(strings.NewReader(tc.input), &standard)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestGearRatioSum(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected int
+	}{
+		{
+			name: "no part numbers",
+			input: `....#..987
+			123.#.....
+			....#.3...`,
+			expected: 0,
+		},
+		{
+			name: "four part numbers, not gears",
+			input: `....10....
+			..10##10..
+			....10....`,
+			expected: 0,
+		},
+		{
+			name: "single gear",
+			input: `....10....
+			....*10...`,
+			expected: 100,
+		},
+		{
+			name: "example",
+			input: `467..114..
+			...*......
+			..35..633.
+			......#...
+			617*......
+			.....+.58.
+			..592.....
+			......755.
+			...$.*....
+			.664.598..`,
+			expected: 467835,
+		},
+		{
+			name: "dedupe",
+			input: `....10....
+			..10*10...`,
+			expected: 0,
+		},
+		{
+			name:     "member found after another symbol",
+			input:    `#12*3`,
+			expected: 36,
+		},
+		{
+			name:     "member of two gears",
+			input:    `2*3*4`,
+			expected: 18,
+		},
+		{
+			name: "multibyte neighbours",
+			input: `§§10→→
+			→→*§§§
+			─10───`,
+			expected: 100,
+		},
+		{
+			name: "dedupe with extra",
+			input: `...10...99
+			        .10*10..*1`,
+			expected: 99,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := gearRatioSum(strings.NewReader(tc.input), &standard)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestScanParts(t *testing.T) {
+	testCases := []struct {
+		name     string
+		lines    []string
+		expected []*part
+	}{
+		{
+			name:  "no input",
+			lines: nil,
+		},
+		{
+			name:  "no lines",
+			lines: []string{},
+		},
+		{
+			name: "single line, no numbers",
+			lines: []string{
+				`..........`,
+			},
+		},
+		{
+			name: "single line, with two numbers, no parts",
+			lines: []string{
+				`.2...38...`,
+			},
+		},
+		{
+			name: "single numbers separated by single symbols",
+			lines: []string{
+				`0*2@4!6^8.`,
+			},
+			expected: []*part{
+				{val: 0, Span: grid.Span{Row: 0, Start: 0, End: 0}, symbols: []*symbol{{kind: "*", Point: grid.Point{Row: 0, Col: 1}}}},
+				{val: 2, Span: grid.Span{Row: 0, Start: 2, End: 2}, symbols: []*symbol{{kind: "*", Point: grid.Point{Row: 0, Col: 1}}, {kind: "@", Point: grid.Point{Row: 0, Col: 3}}}},
+				{val: 4, Span: grid.Span{Row: 0, Start: 4, End: 4}, symbols: []*symbol{{kind: "@", Point: grid.Point{Row: 0, Col: 3}}, {kind: "!", Point: grid.Point{Row: 0, Col: 5}}}},
+				{val: 6, Span: grid.Span{Row: 0, Start: 6, End: 6}, symbols: []*symbol{{kind: "!", Point: grid.Point{Row: 0, Col: 5}}, {kind: "^", Point: grid.Point{Row: 0, Col: 7}}}},
+				{val: 8, Span: grid.Span{Row: 0, Start: 8, End: 8}, symbols: []*symbol{{kind: "^", Point: grid.Point{Row: 0, Col: 7}}}},
+			},
+		},
+		{
+			name: "multiple lines",
+			lines: []string{
+				`$...&*2113`,
+				`33!.....99`,
+			},
+			expected: []*part{
+				{val: 2113, Span: grid.Span{Row: 0, Start: 6, End: 9}, symbols: []*symbol{{kind: "*", Point: grid.Point{Row: 0, Col: 5}}}},
+				{val: 33, Span: grid.Span{Row: 1, Start: 0, End: 1}, symbols: []*symbol{{kind: "$", Point: grid.Point{Row: 0, Col: 0}}, {kind: "!", Point: grid.Point{Row: 1, Col: 2}}}},
+			},
+		},
+		{
+			name: "tens",
+			lines: []string{
+				`...10...99`,
+				`.10*10..*1`,
+			},
+			expected: []*part{
+				{val: 10, Span: grid.Span{Row: 0, Start: 3, End: 4}, symbols: []*symbol{{kind: "*", Point: grid.Point{Row: 1, Col: 3}}}},
+				{val: 99, Span: grid.Span{Row: 0, Start: 8, End: 9}, symbols: []*symbol{{kind: "*", Point: grid.Point{Row: 1, Col: 8}}}},
+				{val: 10, Span: grid.Span{Row: 1, Start: 1, End: 2}, symbols: []*symbol{{kind: "*", Point: grid.Point{Row: 1, Col: 3}}}},
+				{val: 10, Span: grid.Span{Row: 1, Start: 4, End: 5}, symbols: []*symbol{{kind: "*", Point: grid.Point{Row: 1, Col: 3}}}},
+				{val: 1, Span: grid.Span{Row: 1, Start: 9, End: 9}, symbols: []*symbol{{kind: "*", Point: grid.Point{Row: 1, Col: 8}}}},
+			},
+		},
+		{
+			name: "multibyte symbols are located by rune",
+			lines: []string{
+				`§12..→7`,
+				`..┼..34`,
+			},
+			expected: []*part{
+				{val: 12, Span: grid.Span{Row: 0, Start: 1, End: 2}, symbols: []*symbol{{kind: "§", Point: grid.Point{Row: 0, Col: 0}}, {kind: "┼", Point: grid.Point{Row: 1, Col: 2}}}},
+				{val: 7, Span: grid.Span{Row: 0, Start: 6, End: 6}, symbols: []*symbol{{kind: "→", Point: grid.Point{Row: 0, Col: 5}}}},
+				{val: 34, Span: grid.Span{Row: 1, Start: 5, End: 6}, symbols: []*symbol{{kind: "→", Point: grid.Point{Row: 0, Col: 5}}}},
+			},
+		},
+		{
+			name: "colon is a symbol, not a digit",
+			lines: []string{
+				`12:.9`,
+			},
+			expected: []*part{
+				{val: 12, Span: grid.Span{Row: 0, Start: 0, End: 1}, symbols: []*symbol{{kind: ":", Point: grid.Point{Row: 0, Col: 2}}}},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := collectParts(tc.lines)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestScanPartsErrors(t *testing.T) {
+	lines := []string{
+		`12.*......`,
+		`..99999999999999999999*`,
+		`........*.`,
+		`.123456789012345678901`,
+		`→→99999999999999999999§`,
+	}
+
+	_, err := collectParts(lines)
+
+	require.Error(t, err)
+	assert.Equal(t, `line 2, column 3: converting part number "99999999999999999999" to int: strconv.Atoi: parsing "99999999999999999999": value out of range
+line 4, column 2: converting part number "123456789012345678901" to int: strconv.Atoi: parsing "123456789012345678901": value out of range
+line 5, column 3: converting part number "99999999999999999999" to int: strconv.Atoi: parsing "99999999999999999999": value out of range`, err.Error())
+
+	var pe *aoc.ParseError
+	require.ErrorAs(t, err, &pe)
+	assert.Equal(t, aoc.ParseError{Line: 2, Column: 3, Token: "99999999999999999999", Err: pe.Err}, *pe)
+}
+
+// TestScanPartsStreams feeds the schematic a row at a time, checking each part is handed over as
+// soon as the row below it arrives rather than once the whole schematic has been read.
+func TestScanPartsStreams(t *testing.T) {
+	pr, pw := io.Pipe()
+
+	found := make(chan int)
+	done := make(chan error)
+	go func() {
+		done <- scanParts(pr, &standard, func(p *part) { found <- p.val })
+	}()
+
+	rows := []string{"1.........", "#.23......", "...*45....", "........%6"}
+	for i, row := range rows {
+		_, err := io.WriteString(pw, row+"\n")
+		require.NoError(t, err)
+
+		switch i {
+		case 1:
+			assert.Equal(t, 1, <-found)
+		case 2:
+			assert.Equal(t, 23, <-found)
+		case 3:
+			assert.Equal(t, 45, <-found)
+		}
+	}
+	require.NoError(t, pw.Close())
+
+	assert.Equal(t, 6, <-found)
+	require.NoError(t, <-done)
+}
+
+func TestGearRatioSumLongSchematic(t *testing.T) {
+	const blocks = 50000
+	var b strings.Builder
+	for i := 0; i < blocks; i++ {
+		b.WriteString("12*3..7\n.....*2\n.......\n")
+	}
+
+	actual, err := gearRatioSum(strings.NewReader(b.String()), &standard)
+
+	require.NoError(t, err)
+	assert.Equal(t, blocks*(36+14), actual)
+}
+
+func TestGearRatioSumWideSchematic(t *testing.T) {
+	const blocks = 50000
+	top := strings.Repeat("2*3...", blocks)
+	bottom := strings.Repeat("...4*5", blocks)
+
+	actual, err := gearRatioSum(strings.NewReader(top+"\n"+bottom+"\n"), &standard)
+
+	require.NoError(t, err)
+	assert.Equal(t, blocks*(6+20), actual)
+}
+
+// gearRatioSum evaluates the puzzle's rule for gears alone, as part 2 does by default.
+func gearRatioSum(r io.Reader, d *dialect) (int, error) {
+	rep, err := evaluate(r, d, rules{gearRule(d)})
+	if err != nil {
+		return 0, err
+	}
+
+	return rep.total, nil
+}
+
+// collectParts gathers every part found in lines, or none if any cannot be read.
+func collectParts(lines []string) ([]*part, error) {
+	var ps []*part
+	if err := scanParts(strings.NewReader(strings.Join(lines, "\n")), &standard, func(p *part) { ps = append(ps, p) }); err != nil {
+		return nil, err
+	}
+
+	return ps, nil
+}
+
+func TestIsPartNumber(t *testing.T) {
+	testCases := []struct {
+		name       string
+		lines      []string
+		part       *part
+		lineNum    int
+		pos        []int
+		expected   *part
+		expectedOK bool
+	}{
+		{
+			name: "line number greater than lines",
+			lines: []string{
+				"..........",
+				"..........",
+			},
+			part:       &part{Span: grid.Span{Row: 2}},
+			expectedOK: false,
+		},
+		{
+			name:       "start less than 0",
+			part:       &part{Span: grid.Span{Start: -1}},
+			expectedOK: false,
+		},
+		{
+			name: "end greater than line length",
+			lines: []string{
+				"..........",
+			},
+			part:       &part{Span: grid.Span{End: 200}},
+			expectedOK: false,
+		},
+		{
+			name: "middle area part number via prev row start diag",
+			lines: []string{
+				".....*....",
+				"......33..",
+				"..........",
+			},
+			part: &part{
+				Span: grid.Span{Row: 1, Start: 6, End: 7},
+			},
+			expected: &part{
+				Span:    grid.Span{Row: 1, Start: 6, End: 7},
+				symbols: []*symbol{{kind: "*", Point: grid.Point{Row: 0, Col: 5}}},
+			},
+			expectedOK: true,
+		},
+		{
+			name: "middle area number via prev row end diag",
+			lines: []string{
+				"........!.",
+				"......33..",
+				"..........",
+			},
+			part: &part{
+				Span: grid.Span{Row: 1, Start: 6, End: 7},
+			},
+			expected: &part{
+				Span:    grid.Span{Row: 1, Start: 6, End: 7},
+				symbols: []*symbol{{kind: "!", Point: grid.Point{Row: 0, Col: 8}}},
+			},
+			expectedOK: true,
+		},
+		{
+			name: "middle area number via same row before num",
+			lines: []string{
+				"..........",
+				".....$33..",
+				"..........",
+			},
+			part: &part{
+				Span: grid.Span{Row: 1, Start: 6, End: 7},
+			},
+			expected: &part{
+				Span:    grid.Span{Row: 1, Start: 6, End: 7},
+				symbols: []*symbol{{kind: "$", Point: grid.Point{Row: 1, Col: 5}}},
+			},
+			expectedOK: true,
+		},
+		{
+			name: "middle area number via same row after num",
+			lines: []string{
+				"..........",
+				"..23(.....",
+				"..........",
+			},
+			part: &part{
+				Span: grid.Span{Row: 1, Start: 2, End: 3},
+			},
+			expected: &part{
+				Span:    grid.Span{Row: 1, Start: 2, End: 3},
+				symbols: []*symbol{{kind: "(", Point: grid.Point{Row: 1, Col: 4}}},
+			},
+			expectedOK: true,
+		},
+		{
+			name: "middle area number prev row start diag",
+			lines: []string{
+				".#........",
+				"..23......",
+				"..........",
+			},
+			part: &part{
+				Span: grid.Span{Row: 1, Start: 2, End: 3},
+			},
+			expected: &part{
+				Span:    grid.Span{Row: 1, Start: 2, End: 3},
+				symbols: []*symbol{{kind: "#", Point: grid.Point{Row: 0, Col: 1}}},
+			},
+			expectedOK: true,
+		},
+		{
+			name: "middle area number prev row end diag",
+			lines: []string{
+				"....#.....",
+				"..23......",
+				"..........",
+			},
+			part: &part{
+				Span: grid.Span{Row: 1, Start: 2, End: 3},
+			},
+			expected: &part{
+				Span:    grid.Span{Row: 1, Start: 2, End: 3},
+				symbols: []*symbol{{kind: "#", Point: grid.Point{Row: 0, Col: 4}}},
+			},
+			expectedOK: true,
+		},
+		{
+			name: "middle area number next row start diag",
+			lines: []string{
+				"..........",
+				"..23......",
+				".%........",
+			},
+			part: &part{
+				Span: grid.Span{Row: 1, Start: 2, End: 3},
+			},
+			expected: &part{
+				Span:    grid.Span{Row: 1, Start: 2, End: 3},
+				symbols: []*symbol{{kind: "%", Point: grid.Point{Row: 2, Col: 1}}},
+			},
+			expectedOK: true,
+		},
+		{
+			name: "middle area number next row end diag",
+			lines: []string{
+				"..........",
+				"..23......",
+				"....@.....",
+			},
+			part: &part{
+				Span: grid.Span{Row: 1, Start: 2, End: 3},
+			},
+			expected: &part{
+				Span:    grid.Span{Row: 1, Start: 2, End: 3},
+				symbols: []*symbol{{kind: "@", Point: grid.Point{Row: 2, Col: 4}}},
+			},
+			expectedOK: true,
+		},
+		{
+			name: "first line number symbol after",
+			lines: []string{
+				"....2113#.",
+				"..........",
+			},
+			part: &part{
+				Span: grid.Span{Row: 0, Start: 4, End: 7},
+			},
+			expected: &part{
+				Span:    grid.Span{Row: 0, Start: 4, End: 7},
+				symbols: []*symbol{{kind: "#", Point: grid.Point{Row: 0, Col: 8}}},
+			},
+			expectedOK: true,
+		},
+		{
+			name: "first line number symbol before",
+			lines: []string{
+				"^499......",
+				"..........",
+			},
+			part: &part{
+				Span: grid.Span{Row: 0, Start: 1, End: 3},
+			},
+			expected: &part{
+				Span:    grid.Span{Row: 0, Start: 1, End: 3},
+				symbols: []*symbol{{kind: "^", Point: grid.Point{Row: 0, Col: 0}}},
+			},
+			expectedOK: true,
+		},
+		{
+			name: "first line end number symbol before",
+			lines: []string{
+				"........&0",
+				"..........",
+			},
+			part: &part{
+				Span: grid.Span{Row: 0, Start: 9, End: 9},
+			},
+			expected: &part{
+				Span:    grid.Span{Row: 0, Start: 9, End: 9},
+				symbols: []*symbol{{kind: "&", Point: grid.Point{Row: 0, Col: 8}}},
+			},
+			expectedOK: true,
+		},
+		{
+			name: "first line almost end number symbol after",
+			lines: []string{
+				"........1*",
+				"..........",
+			},
+			part: &part{
+				Span: grid.Span{Row: 0, Start: 8, End: 8},
+			},
+			expected: &part{
+				Span:    grid.Span{Row: 0, Start: 8, End: 8},
+				symbols: []*symbol{{kind: "*", Point: grid.Point{Row: 0, Col: 9}}},
+			},
+			expectedOK: true,
+		},
+		{
+			name: "first line next row start diag",
+			lines: []string{
+				"...123....",
+				"..).......",
+			},
+			part: &part{
+				Span: grid.Span{Row: 0, Start: 3, End: 5},
+			},
+			expected: &part{
+				Span:    grid.Span{Row: 0, Start: 3, End: 5},
+				symbols: []*symbol{{kind: ")", Point: grid.Point{Row: 1, Col: 2}}},
+			},
+			expectedOK: true,
+		},
+		{
+			name: "first line next row after diag",
+			lines: []string{
+				"...123....",
+				"......_...",
+			},
+			part: &part{
+				Span: grid.Span{Row: 0, Start: 3, End: 5},
+			},
+			expected: &part{
+				Span:    grid.Span{Row: 0, Start: 3, End: 5},
+				symbols: []*symbol{{kind: "_", Point: grid.Point{Row: 1, Col: 6}}},
+			},
+			expectedOK: true,
+		},
+		{
+			name: "end line mid number symbol after",
+			lines: []string{
+				"..........",
+				"..........",
+				"....4123(.",
+			},
+			part: &part{
+				Span: grid.Span{Row: 2, Start: 4, End: 7},
+			},
+			expected: &part{
+				Span:    grid.Span{Row: 2, Start: 4, End: 7},
+				symbols: []*symbol{{kind: "(", Point: grid.Point{Row: 2, Col: 8}}},
+			},
+			expectedOK: true,
+		},
+		{
+			name: "end line mid number prev line start diag",
+			lines: []string{
+				"..........",
+				"^.........",
+				".9874123..",
+			},
+			part: &part{
+				Span: grid.Span{Row: 2, Start: 1, End: 7},
+			},
+			expected: &part{
+				Span:    grid.Span{Row: 2, Start: 1, End: 7},
+				symbols: []*symbol{{kind: "^", Point: grid.Point{Row: 1, Col: 0}}},
+			},
+			expectedOK: true,
+		},
+		{
+			name: "end line mid number prev line end dig",
+			lines: []string{
+				"..........",
+				"........~.",
+				".9874123..",
+			},
+			part: &part{
+				Span: grid.Span{Row: 2, Start: 1, End: 7},
+			},
+			expected: &part{
+				Span:    grid.Span{Row: 2, Start: 1, End: 7},
+				symbols: []*symbol{{kind: "~", Point: grid.Point{Row: 1, Col: 8}}},
+			},
+			expectedOK: true,
+		},
+		{
+			name: "not part number symbols all around with one gap",
+			lines: []string{
+				"&&&&&&&&&&",
+				"~~......@@",
+				"!!.1111.##",
+				"$$......^^",
+				"**********",
+			},
+			part: &part{
+				Span: grid.Span{Row: 2, Start: 3, End: 6},
+			},
+			expectedOK: false,
+		},
+		{
+			name: "every adjacent symbol in reading order",
+			lines: []string{
+				"...#......",
+				"..23*.....",
+				".$........",
+			},
+			part: &part{
+				Span: grid.Span{Row: 1, Start: 2, End: 3},
+			},
+			expected: &part{
+				Span: grid.Span{Row: 1, Start: 2, End: 3},
+				symbols: []*symbol{
+					{kind: "#", Point: grid.Point{Row: 0, Col: 3}},
+					{kind: "*", Point: grid.Point{Row: 1, Col: 4}},
+					{kind: "$", Point: grid.Point{Row: 2, Col: 1}},
+				},
+			},
+			expectedOK: true,
+		},
+		{
+			name: "not part number at start of line",
+			lines: []string{
+				"..........",
+				"1111.@@@##",
+				"..........",
+			},
+			part: &part{
+				Span: grid.Span{Row: 1, Start: 0, End: 3},
+			},
+			expectedOK: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, ok := isPartNumber(grid.New(tc.lines), &standard, tc.part)
+
+			assert.Equal(t, tc.expected, actual)
+			assert.Equal(t, tc.expectedOK, ok)
+		})
+	}
+}
+
+// configuredSolver returns a new solver with its flags parsed from args.
+func configuredSolver(t *testing.T, args ...string) *solver {
+	t.Helper()
+
+	s := newSolver()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	s.Flags(fs)
+	require.NoError(t, fs.Parse(args))
+
+	return s
+}
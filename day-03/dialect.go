@@ -0,0 +1,188 @@
+package day03
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mxygem/advent-of-code-2023/aoc"
+)
+
+// dialect describes how a schematic is written. Every rune that is not a digit, blank or ignored is
+// a symbol, but only markers make the numbers next to them part numbers.
+type dialect struct {
+	// blank holds the runes standing for empty cells.
+	blank string
+	// ignored holds the runes removed from every row before the schematic is laid out, so they take
+	// up no cell at all.
+	ignored string
+	// markers holds the symbols marking part numbers, every symbol being one when empty.
+	markers string
+	// gear is the symbol acting as a gear.
+	gear string
+}
+
+// standard is the dialect of the puzzle itself.
+var standard = dialect{blank: ".", gear: "*"}
+
+// isBlank reports whether c stands for an empty cell.
+func (d *dialect) isBlank(c rune) bool {
+	return strings.ContainsRune(d.blank, c)
+}
+
+// isMarker reports whether c is a symbol marking the numbers next to it as part numbers.
+func (d *dialect) isMarker(c rune) bool {
+	if isDigit(c) || d.isBlank(c) {
+		return false
+	}
+
+	return d.markers == "" || strings.ContainsRune(d.markers, c)
+}
+
+// strip removes the dialect's ignored runes from row.
+func (d *dialect) strip(row string) string {
+	if d.ignored == "" {
+		return row
+	}
+
+	return strings.Map(func(c rune) rune {
+		if strings.ContainsRune(d.ignored, c) {
+			return -1
+		}
+		return c
+	}, row)
+}
+
+// validate checks the runes of each class can be told apart.
+func (d *dialect) validate() error {
+	classes := []struct{ name, runes string }{
+		{"blank", d.blank},
+		{"ignored", d.ignored},
+		{"marker", d.markers},
+	}
+
+	var errs []error
+	for i, class := range classes {
+		for _, c := range class.runes {
+			if isDigit(c) {
+				errs = append(errs, fmt.Errorf("digit %q cannot be listed as %s", c, class.name))
+			}
+
+			for _, other := range classes[i+1:] {
+				if strings.ContainsRune(other.runes, c) {
+					errs = append(errs, fmt.Errorf("%q is listed as both %s and %s", c, class.name, other.name))
+				}
+			}
+		}
+	}
+
+	switch g, size := utf8.DecodeRuneInString(d.gear); {
+	case d.gear == "":
+		errs = append(errs, fmt.Errorf("no gear symbol given"))
+	case size != len(d.gear):
+		errs = append(errs, fmt.Errorf("gear %q must be a single symbol", d.gear))
+	case !d.isMarker(g) || strings.ContainsRune(d.ignored, g):
+		errs = append(errs, fmt.Errorf("gear %q is not a part marker", d.gear))
+	}
+
+	return errors.Join(errs...)
+}
+
+// loadDialect reads a dialect file from loc, starting from base for anything the file leaves out.
+func loadDialect(loc string, base dialect) (dialect, error) {
+	f, err := os.Open(loc)
+	if err != nil {
+		return dialect{}, fmt.Errorf("opening dialect: %w", err)
+	}
+	defer f.Close()
+
+	d, err := readDialect(f, base)
+	if err != nil {
+		return dialect{}, aoc.InFile(err, loc)
+	}
+
+	return d, nil
+}
+
+// readDialect reads one key=value setting per line, such as "blank=.~", over base. The keys are
+// blank, ignore, markers and gear, taking the same values as the flags of the same names. Blank
+// lines and lines starting with # are ignored.
+func readDialect(r io.Reader, base dialect) (dialect, error) {
+	d := base
+
+	var errs []error
+	err := aoc.ScanLines(r, func(line int, raw string) {
+		text := strings.TrimSpace(raw)
+		if text == "" || strings.HasPrefix(text, "#") {
+			return
+		}
+
+		key, value, ok := strings.Cut(text, "=")
+		if !ok {
+			errs = append(errs, &aoc.ParseError{
+				Line:   line,
+				Column: 1,
+				Token:  text,
+				Err:    fmt.Errorf("invalid setting %q, expected key=value", text),
+			})
+			return
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+
+		switch key {
+		case "blank":
+			d.blank = value
+		case "ignore":
+			d.ignored = value
+		case "markers":
+			d.markers = value
+		case "gear":
+			d.gear = value
+		default:
+			errs = append(errs, &aoc.ParseError{
+				Line:   line,
+				Column: strings.Index(raw, key) + 1,
+				Token:  key,
+				Err:    fmt.Errorf("unknown setting %q, expected one of blank, ignore, markers, gear", key),
+			})
+		}
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return dialect{}, errors.Join(errs...)
+	}
+
+	return d, nil
+}
+
+// setting is a string flag remembering whether it was given, letting it override a dialect file.
+type setting struct {
+	value string
+	given bool
+}
+
+func (s *setting) String() string {
+	if s == nil {
+		return ""
+	}
+
+	return s.value
+}
+
+func (s *setting) Set(v string) error {
+	s.value, s.given = v, true
+	return nil
+}
+
+// apply sets dst to the setting's value if it was given.
+func (s *setting) apply(dst *string) {
+	if s.given {
+		*dst = s.value
+	}
+}
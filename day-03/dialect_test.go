@@ -0,0 +1,229 @@
+package day03
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mxygem/advent-of-code-2023/aoc"
+)
+
+func TestDialect(t *testing.T) {
+	testCases := []struct {
+		name          string
+		dialect       dialect
+		input         string
+		expectedParts int
+		expectedGears int
+	}{
+		{
+			name:    "several blank characters",
+			dialect: dialect{blank: ". ", gear: "*"},
+			input: `12 .*3
+			....  `,
+			expectedParts: 3,
+		},
+		{
+			name:    "ignored characters take up no cell",
+			dialect: dialect{blank: ".", ignored: "|", gear: "*"},
+			input: `|4|.|.|
+			|.|*|7|`,
+			expectedParts: 11,
+			expectedGears: 28,
+		},
+		{
+			name:    "only markers make part numbers",
+			dialect: dialect{blank: ".", markers: "x@", gear: "x"},
+			input: `5#.6@.
+			..x...
+			.8....`,
+			expectedParts: 6 + 8,
+			expectedGears: 48,
+		},
+		{
+			name:    "other gear symbol",
+			dialect: dialect{blank: ".", gear: "@"},
+			input: `2*3
+			..@5`,
+			expectedParts: 10,
+			expectedGears: 15,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, tc.dialect.validate())
+
+			parts, err := partNumberSum(strings.NewReader(tc.input), &tc.dialect)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedParts, parts)
+
+			gears, err := gearRatioSum(strings.NewReader(tc.input), &tc.dialect)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedGears, gears)
+		})
+	}
+}
+
+func TestDialectValidate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		dialect     dialect
+		expectedErr error
+	}{
+		{
+			name:    "standard",
+			dialect: standard,
+		},
+		{
+			name:        "digit",
+			dialect:     dialect{blank: ".0", gear: "*"},
+			expectedErr: fmt.Errorf(`digit '0' cannot be listed as blank`),
+		},
+		{
+			name:        "overlapping classes",
+			dialect:     dialect{blank: ".", ignored: "|.", markers: "*|", gear: "*"},
+			expectedErr: fmt.Errorf("'.' is listed as both blank and ignored\n'|' is listed as both ignored and marker"),
+		},
+		{
+			name:        "no gear",
+			dialect:     dialect{blank: "."},
+			expectedErr: fmt.Errorf("no gear symbol given"),
+		},
+		{
+			name:        "gear of several symbols",
+			dialect:     dialect{blank: ".", gear: "**"},
+			expectedErr: fmt.Errorf(`gear "**" must be a single symbol`),
+		},
+		{
+			name:        "gear is not a marker",
+			dialect:     dialect{blank: ".", markers: "#", gear: "*"},
+			expectedErr: fmt.Errorf(`gear "*" is not a part marker`),
+		},
+		{
+			name:        "gear is blank",
+			dialect:     dialect{blank: ".*", gear: "*"},
+			expectedErr: fmt.Errorf(`gear "*" is not a part marker`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.dialect.validate()
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestReadDialect(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       string
+		expected    dialect
+		expectedErr error
+	}{
+		{
+			name:     "empty keeps the base",
+			input:    "",
+			expected: standard,
+		},
+		{
+			name: "settings with comments and blank lines",
+			input: `# box drawn schematics
+			blank = .─
+
+			ignore=│
+			markers = *#
+			gear=#`,
+			expected: dialect{blank: ".─", ignored: "│", markers: "*#", gear: "#"},
+		},
+		{
+			name: "invalid settings",
+			input: `blank .
+			  colour = red`,
+			expectedErr: fmt.Errorf(`line 1, column 1: invalid setting "blank .", expected key=value
+line 2, column 6: unknown setting "colour", expected one of blank, ignore, markers, gear`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := readDialect(strings.NewReader(tc.input), standard)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestSolverDialect(t *testing.T) {
+	dir := t.TempDir()
+	loc := filepath.Join(dir, "dialect.txt")
+	require.NoError(t, os.WriteFile(loc, []byte("blank=.~\ngear=@\n"), 0o600))
+
+	input := "3@2~\n..*4"
+
+	testCases := []struct {
+		name        string
+		args        []string
+		expected    aoc.Answer
+		expectedErr error
+	}{
+		{
+			name:     "standard",
+			expected: 8,
+		},
+		{
+			name:     "file",
+			args:     []string{"-dialect", loc},
+			expected: 6,
+		},
+		{
+			name:     "flags override the file",
+			args:     []string{"-dialect", loc, "-gear", "*"},
+			expected: 8,
+		},
+		{
+			name:        "invalid",
+			args:        []string{"-markers", "#"},
+			expectedErr: fmt.Errorf(`invalid schematic dialect: gear "*" is not a part marker`),
+		},
+		{
+			name:        "missing file",
+			args:        []string{"-dialect", filepath.Join(dir, "missing.txt")},
+			expectedErr: fmt.Errorf("opening dialect: open %s: no such file or directory", filepath.Join(dir, "missing.txt")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := configuredSolver(t, tc.args...)
+
+			actual, err := s.Part2(strings.NewReader(input))
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
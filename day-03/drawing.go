@@ -0,0 +1,305 @@
+package day03
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mxygem/advent-of-code-2023/grid"
+)
+
+const (
+	// cellSize is the width and height of a cell in pixels.
+	cellSize = 16
+	// margin is the space left around everything drawn, in pixels.
+	margin = cellSize
+	// glyphScale is the size in pixels of a dot of the glyphs drawn in pictures without fonts.
+	glyphScale = 2
+	// labelAdvance is the width taken by each character of an evaluated symbol's label.
+	labelAdvance = 4 * glyphScale
+	// labelHeight is the height of an evaluated symbol's label.
+	labelHeight = 5 * glyphScale
+	// labelGap is the space left between the widest row and the labels beside it, and between two
+	// labels of the same row.
+	labelGap = cellSize
+)
+
+var (
+	// fills holds the background of every kind of cell.
+	fills = map[cell]color.RGBA{
+		blankCell:  {0xff, 0xff, 0xff, 0xff},
+		partCell:   {0xd9, 0xea, 0xd3, 0xff},
+		numberCell: {0xf4, 0xcc, 0xcc, 0xff},
+		symbolCell: {0xff, 0xf2, 0xcc, 0xff},
+		gearCell:   {0xea, 0xd1, 0xdc, 0xff},
+	}
+	gridColor = color.RGBA{0xdd, 0xdd, 0xdd, 0xff}
+	inkColor  = color.RGBA{0x00, 0x00, 0x00, 0xff}
+	boxColor  = color.RGBA{0x38, 0x76, 0x1d, 0xff}
+	gearColor = color.RGBA{0xa6, 0x1c, 0x5c, 0xff}
+)
+
+// pictureFormats are the extensions of the files a schematic can be drawn to.
+var pictureFormats = []string{".svg", ".png"}
+
+// checkPictureFormat returns an error unless loc has one of pictureFormats as its extension.
+func checkPictureFormat(loc string) error {
+	ext := filepath.Ext(loc)
+	if slices.Contains(pictureFormats, ext) {
+		return nil
+	}
+
+	return fmt.Errorf("unknown picture format %q, expected one of %s", ext, strings.Join(pictureFormats, ", "))
+}
+
+// save draws the schematic to a new file at loc, in the format given by its extension.
+func (sc *schematic) save(loc string) error {
+	if err := checkPictureFormat(loc); err != nil {
+		return err
+	}
+
+	f, err := os.Create(loc)
+	if err != nil {
+		return fmt.Errorf("creating picture: %w", err)
+	}
+
+	if filepath.Ext(loc) == ".svg" {
+		err = sc.writeSVG(f)
+	} else {
+		err = sc.writePNG(f)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("drawing schematic to %s: %w", loc, err)
+	}
+
+	return nil
+}
+
+// cellRect returns the pixels taken up by the cell at p.
+func cellRect(p grid.Point) image.Rectangle {
+	x, y := margin+p.Col*cellSize, margin+p.Row*cellSize
+	return image.Rect(x, y, x+cellSize, y+cellSize)
+}
+
+// spanRect returns the pixels taken up by the cells of s.
+func spanRect(s grid.Span) image.Rectangle {
+	return cellRect(grid.Point{Row: s.Row, Col: s.Start}).Union(cellRect(grid.Point{Row: s.Row, Col: s.End}))
+}
+
+// center returns the pixel in the middle of r.
+func center(r image.Rectangle) image.Point {
+	return r.Min.Add(r.Max).Div(2)
+}
+
+// labels returns the pixels taken up by the label of every evaluated symbol, in the same order.
+// Labels are set out in a gutter to the right of the widest row, level with the row of their symbol
+// and in the order of the symbols along it, so that none hides a cell. Labels are drawn over a
+// backing glyphScale pixels wider on every side.
+func (sc *schematic) labels() []image.Rectangle {
+	var cols int
+	for row := 0; row < sc.g.Rows(); row++ {
+		cols = max(cols, sc.g.Cols(row))
+	}
+	gutter := margin + cols*cellSize + labelGap
+
+	out := make([]image.Rectangle, len(sc.evaluated))
+	next := map[int]int{}
+	for i, e := range sc.evaluated {
+		x, ok := next[e.Row]
+		if !ok {
+			x = gutter
+		}
+		corner := image.Pt(x, center(cellRect(e.Point)).Y-labelHeight/2)
+		out[i] = image.Rectangle{Min: corner, Max: corner.Add(image.Pt(len(strconv.Itoa(e.value))*labelAdvance, labelHeight))}
+		next[e.Row] = out[i].Max.X + labelGap
+	}
+
+	return out
+}
+
+// canvas returns the size of a picture holding every cell and label along with the margin around
+// them.
+func (sc *schematic) canvas() image.Rectangle {
+	var used image.Rectangle
+	for row := 0; row < sc.g.Rows(); row++ {
+		if cols := sc.g.Cols(row); cols > 0 {
+			used = used.Union(spanRect(grid.Span{Row: row, End: cols - 1}))
+		}
+	}
+	for _, label := range sc.labels() {
+		used = used.Union(label.Inset(-glyphScale))
+	}
+
+	return image.Rect(0, 0, used.Max.X+margin, used.Max.Y+margin)
+}
+
+// hex formats c as an SVG colour.
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// writeSVG draws the schematic as an SVG document: every cell with its rune, a box around every part
+// and a line from every evaluated symbol, such as a gear, to each of its parts, the symbol labelled
+// with the value its rule gave it, such as the gear's ratio.
+func (sc *schematic) writeSVG(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	size := sc.canvas().Max
+
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %[1]d %[2]d" font-family="monospace" font-size="12">`+"\n", size.X, size.Y)
+	fmt.Fprintf(bw, `<rect width="%d" height="%d" fill="%s"/>`+"\n", size.X, size.Y, hex(fills[blankCell]))
+
+	for row, kinds := range sc.cells() {
+		sc.g.EachInRow(row, func(p grid.Point, c rune) bool {
+			r := cellRect(p)
+			fmt.Fprintf(bw, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s" stroke="%s"/>`+"\n", r.Min.X, r.Min.Y, cellSize, cellSize, hex(fills[kinds[p.Col]]), hex(gridColor))
+			if kinds[p.Col] != blankCell || !sc.d.isBlank(c) {
+				mid := center(r)
+				fmt.Fprintf(bw, `<text x="%d" y="%d" text-anchor="middle" dominant-baseline="central">`, mid.X, mid.Y)
+				xml.EscapeText(bw, []byte(string(c)))
+				fmt.Fprintln(bw, "</text>")
+			}
+			return true
+		})
+	}
+
+	for _, p := range sc.parts {
+		r := spanRect(p.Span)
+		fmt.Fprintf(bw, `<rect x="%d" y="%d" width="%d" height="%d" fill="none" stroke="%s" stroke-width="2"/>`+"\n", r.Min.X, r.Min.Y, r.Dx(), r.Dy(), hex(boxColor))
+	}
+
+	labels := sc.labels()
+	for i, e := range sc.evaluated {
+		from := center(cellRect(e.Point))
+		for _, p := range e.parts {
+			to := center(spanRect(p.Span))
+			fmt.Fprintf(bw, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s" stroke-width="2"/>`+"\n", from.X, from.Y, to.X, to.Y, hex(gearColor))
+		}
+
+		label := labels[i]
+		backing := label.Inset(-glyphScale)
+		fmt.Fprintf(bw, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s" stroke="%s"/>`+"\n", backing.Min.X, backing.Min.Y, backing.Dx(), backing.Dy(), hex(fills[blankCell]), hex(gearColor))
+		fmt.Fprintf(bw, `<text x="%d" y="%d" fill="%s">%d</text>`+"\n", label.Min.X, label.Max.Y, hex(gearColor), e.value)
+	}
+
+	fmt.Fprintln(bw, "</svg>")
+
+	return bw.Flush()
+}
+
+// writePNG draws the same picture as writeSVG as a PNG image. Without fonts to hand, digits are drawn
+// with glyphs of their own and every other rune with a cross.
+func (sc *schematic) writePNG(w io.Writer) error {
+	img := image.NewRGBA(sc.canvas())
+	draw.Draw(img, img.Bounds(), image.NewUniform(fills[blankCell]), image.Point{}, draw.Src)
+
+	for row, kinds := range sc.cells() {
+		sc.g.EachInRow(row, func(p grid.Point, c rune) bool {
+			r := cellRect(p)
+			draw.Draw(img, r, image.NewUniform(gridColor), image.Point{}, draw.Src)
+			draw.Draw(img, r.Inset(1), image.NewUniform(fills[kinds[p.Col]]), image.Point{}, draw.Src)
+			if kinds[p.Col] != blankCell || !sc.d.isBlank(c) {
+				mid := center(r)
+				drawGlyph(img, c, mid.Sub(image.Pt(3*glyphScale/2, labelHeight/2)), inkColor)
+			}
+			return true
+		})
+	}
+
+	for _, p := range sc.parts {
+		outline(img, spanRect(p.Span), 2, boxColor)
+	}
+
+	labels := sc.labels()
+	for i, e := range sc.evaluated {
+		from := center(cellRect(e.Point))
+		for _, p := range e.parts {
+			line(img, from, center(spanRect(p.Span)), gearColor)
+		}
+
+		label := labels[i]
+		backing := label.Inset(-glyphScale)
+		draw.Draw(img, backing, image.NewUniform(gearColor), image.Point{}, draw.Src)
+		draw.Draw(img, backing.Inset(1), image.NewUniform(fills[blankCell]), image.Point{}, draw.Src)
+		at := label.Min
+		for _, c := range strconv.Itoa(e.value) {
+			drawGlyph(img, c, at, gearColor)
+			at.X += labelAdvance
+		}
+	}
+
+	return png.Encode(w, img)
+}
+
+// glyphs holds a 3 by 5 dot glyph for every digit, one row of dots per entry with the leftmost dot
+// in the highest of its three bits.
+var glyphs = map[rune][5]uint8{
+	'0': {7, 5, 5, 5, 7},
+	'1': {2, 6, 2, 2, 7},
+	'2': {7, 1, 7, 4, 7},
+	'3': {7, 1, 7, 1, 7},
+	'4': {5, 5, 7, 1, 1},
+	'5': {7, 4, 7, 1, 7},
+	'6': {7, 4, 7, 5, 7},
+	'7': {7, 1, 1, 1, 1},
+	'8': {7, 5, 7, 5, 7},
+	'9': {7, 5, 7, 1, 7},
+}
+
+// crossGlyph stands in for every rune without a glyph of its own.
+var crossGlyph = [5]uint8{0, 5, 2, 5, 0}
+
+// drawGlyph draws the glyph of c with its top left corner at at.
+func drawGlyph(img draw.Image, c rune, at image.Point, ink color.Color) {
+	g, ok := glyphs[c]
+	if !ok {
+		g = crossGlyph
+	}
+
+	for y, bits := range g {
+		for x := 0; x < 3; x++ {
+			if bits&(4>>x) == 0 {
+				continue
+			}
+			dot := image.Rect(0, 0, glyphScale, glyphScale).Add(at.Add(image.Pt(x*glyphScale, y*glyphScale)))
+			draw.Draw(img, dot, image.NewUniform(ink), image.Point{}, draw.Src)
+		}
+	}
+}
+
+// outline draws the inside edge of r, width pixels thick.
+func outline(img draw.Image, r image.Rectangle, width int, ink color.Color) {
+	u := image.NewUniform(ink)
+	draw.Draw(img, image.Rect(r.Min.X, r.Min.Y, r.Max.X, r.Min.Y+width), u, image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(r.Min.X, r.Max.Y-width, r.Max.X, r.Max.Y), u, image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(r.Min.X, r.Min.Y, r.Min.X+width, r.Max.Y), u, image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(r.Max.X-width, r.Min.Y, r.Max.X, r.Max.Y), u, image.Point{}, draw.Src)
+}
+
+// line draws a straight line from a to b, both included.
+func line(img draw.Image, a, b image.Point, ink color.Color) {
+	d := b.Sub(a)
+	steps := max(abs(d.X), abs(d.Y), 1)
+	for i := 0; i <= steps; i++ {
+		img.Set(a.X+d.X*i/steps, a.Y+d.Y*i/steps, ink)
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,254 @@
+package day03
+
+import (
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mxygem/advent-of-code-2023/grid"
+)
+
+// svgElements counts the elements of an SVG document by name, along with the text of every text
+// element.
+func svgElements(t *testing.T, doc string) (map[string]int, []string) {
+	t.Helper()
+
+	counts := map[string]int{}
+	var texts []string
+	dec := xml.NewDecoder(strings.NewReader(doc))
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return counts, texts
+		}
+		require.NoError(t, err)
+
+		if el, ok := tok.(xml.StartElement); ok {
+			counts[el.Name.Local]++
+			if el.Name.Local == "text" {
+				var text string
+				require.NoError(t, dec.DecodeElement(&text, &el))
+				texts = append(texts, text)
+			}
+		}
+	}
+}
+
+func TestWriteSVG(t *testing.T) {
+	testCases := []struct {
+		name          string
+		rules         string
+		input         string
+		expectedRects int
+		expectedLines int
+		expectedTexts []string
+	}{
+		{
+			name: "gear",
+			input: `12*3
+			..<.`,
+			// background, 8 cells, 2 part boxes and the label's backing
+			expectedRects: 1 + 8 + 2 + 1,
+			expectedLines: 2,
+			expectedTexts: []string{"1", "2", "*", "3", "<", "36"},
+		},
+		{
+			name:  "rule table",
+			rules: "*=sum:2-,<=count",
+			input: `12*3
+			..<.`,
+			// background, 8 cells, 2 part boxes and the backing of two labels
+			expectedRects: 1 + 8 + 2 + 2,
+			expectedLines: 4,
+			expectedTexts: []string{"1", "2", "*", "3", "<", "15", "2"},
+		},
+		{
+			name:          "no gears",
+			input:         "5.+",
+			expectedRects: 1 + 3 + 0,
+			expectedTexts: []string{"5", "+"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rs := rules{gearRule(&standard)}
+			if tc.rules != "" {
+				require.NoError(t, rs.Set(tc.rules))
+			}
+
+			sc, err := readSchematic(strings.NewReader(tc.input), &standard, rs)
+			require.NoError(t, err)
+
+			var b strings.Builder
+			require.NoError(t, sc.writeSVG(&b))
+
+			counts, texts := svgElements(t, b.String())
+			assert.Equal(t, 1, counts["svg"])
+			assert.Equal(t, tc.expectedRects, counts["rect"])
+			assert.Equal(t, tc.expectedLines, counts["line"])
+			assert.Equal(t, tc.expectedTexts, texts)
+		})
+	}
+}
+
+func TestWritePNG(t *testing.T) {
+	sc, err := readSchematic(strings.NewReader(example), &standard, rules{gearRule(&standard)})
+	require.NoError(t, err)
+
+	var b strings.Builder
+	require.NoError(t, sc.writePNG(&b))
+
+	img, err := png.Decode(strings.NewReader(b.String()))
+	require.NoError(t, err)
+	assert.Equal(t, sc.canvas(), img.Bounds())
+
+	// just inside the corner of a cell, clear of its glyph, any box and any line
+	fill := func(p grid.Point) image.Point {
+		return cellRect(p).Min.Add(image.Pt(cellSize-3, cellSize-3))
+	}
+	testCases := []struct {
+		name     string
+		at       image.Point
+		expected any
+	}{
+		{name: "blank", at: fill(grid.Point{Row: 3, Col: 0}), expected: fills[blankCell]},
+		{name: "number", at: fill(grid.Point{Row: 0, Col: 7}), expected: fills[numberCell]},
+		{name: "symbol", at: fill(grid.Point{Row: 3, Col: 6}), expected: fills[symbolCell]},
+		{name: "gear", at: fill(grid.Point{Row: 1, Col: 3}), expected: fills[gearCell]},
+		{name: "part box", at: cellRect(grid.Point{Row: 0, Col: 1}).Min, expected: boxColor},
+		{name: "margin", at: image.Pt(1, 1), expected: fills[blankCell]},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, img.At(tc.at.X, tc.at.Y))
+		})
+	}
+}
+
+func TestLabelsClearOfCells(t *testing.T) {
+	testCases := []struct {
+		name  string
+		rules string
+		input string
+	}{
+		{
+			name:  "example",
+			input: example,
+		},
+		{
+			name:  "several labels in a row",
+			rules: "*=sum:1-,#=count",
+			input: `1*2.3*4.5#6
+			.7.....*8..
+			12*34......`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rs := rules{gearRule(&standard)}
+			if tc.rules != "" {
+				require.NoError(t, rs.Set(tc.rules))
+			}
+
+			sc, err := readSchematic(strings.NewReader(tc.input), &standard, rs)
+			require.NoError(t, err)
+
+			labels := sc.labels()
+			require.Len(t, labels, len(sc.evaluated))
+			for i, label := range labels {
+				backing := label.Inset(-glyphScale)
+				assert.True(t, backing.In(sc.canvas()), "label %d is outside the picture", i)
+				for row := 0; row < sc.g.Rows(); row++ {
+					sc.g.EachInRow(row, func(p grid.Point, _ rune) bool {
+						assert.False(t, backing.Overlaps(cellRect(p)), "label %d hides the cell at %v", i, p)
+						return true
+					})
+				}
+				for j, other := range labels[:i] {
+					assert.False(t, backing.Overlaps(other.Inset(-glyphScale)), "labels %d and %d overlap", j, i)
+				}
+			}
+		})
+	}
+}
+
+func TestSolverDraw(t *testing.T) {
+	dir := t.TempDir()
+
+	testCases := []struct {
+		name        string
+		picture     string
+		expectedErr error
+	}{
+		{
+			name:    "svg",
+			picture: filepath.Join(dir, "schematic.svg"),
+		},
+		{
+			name:    "png",
+			picture: filepath.Join(dir, "schematic.png"),
+		},
+		{
+			name:        "unknown format",
+			picture:     filepath.Join(dir, "schematic.gif"),
+			expectedErr: fmt.Errorf(`unknown picture format ".gif", expected one of .svg, .png`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := configuredSolver(t, "-draw", tc.picture)
+
+			actual, err := s.Part2(strings.NewReader(example))
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.EqualValues(t, 467835, actual)
+
+			var b strings.Builder
+			require.NoError(t, s.Report(&b))
+			assert.Empty(t, b.String(), "drawing prints nothing")
+
+			info, err := os.Stat(tc.picture)
+			require.NoError(t, err)
+			assert.NotZero(t, info.Size())
+		})
+	}
+}
+
+// TestSolverDrawFollowsRules checks the symbols drawn are those evaluated by the solver's rules,
+// whichever part is solved.
+func TestSolverDrawFollowsRules(t *testing.T) {
+	s := configuredSolver(t, "-rules", "*=sum:2-", "-draw", filepath.Join(t.TempDir(), "schematic.svg"))
+
+	answer, err := s.Part2(strings.NewReader(example))
+	require.NoError(t, err)
+
+	drawn := func() int {
+		var total int
+		for _, e := range s.drawn.evaluated {
+			total += e.value
+		}
+		return total
+	}
+	assert.EqualValues(t, answer, drawn())
+
+	_, err = s.Part1(strings.NewReader(example))
+	require.NoError(t, err)
+	assert.EqualValues(t, answer, drawn())
+}
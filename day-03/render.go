@@ -0,0 +1,171 @@
+package day03
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// renderStyles are the ways a schematic can be rendered on a terminal.
+var renderStyles = []string{"ansi", "mono"}
+
+// checkRenderStyle returns an error unless style is one of renderStyles.
+func checkRenderStyle(style string) error {
+	if !slices.Contains(renderStyles, style) {
+		return fmt.Errorf("unknown render style %q, expected one of %s", style, strings.Join(renderStyles, ", "))
+	}
+
+	return nil
+}
+
+// ansiColors holds the SGR parameters of the colour of every kind of cell rendered in the ansi
+// style. Cells without one are printed as they are.
+var ansiColors = map[cell]string{
+	partCell:   "32",
+	numberCell: "31",
+	symbolCell: "33",
+	gearCell:   "1;35",
+}
+
+// monoBrackets holds the brackets put around every kind of cell rendered in the mono style. Cells
+// without any are printed as they are.
+var monoBrackets = map[cell][2]string{
+	partCell:   {"[", "]"},
+	numberCell: {"(", ")"},
+	symbolCell: {"<", ">"},
+	gearCell:   {"{", "}"},
+}
+
+// render prints the rows and columns of the schematic within c, marking parts, other numbers,
+// symbols and gears in the given style. Neighbouring cells of the same kind are marked together so
+// every number is marked as a whole, except for symbols and gears which are always marked one by
+// one.
+func (sc *schematic) render(w io.Writer, style string, c crop) error {
+	// mark returns what goes before and after a run of cells of kind k, if they are marked at all
+	var mark func(k cell) (string, string, bool)
+	switch style {
+	case "ansi":
+		mark = func(k cell) (string, string, bool) {
+			color, ok := ansiColors[k]
+			return "\x1b[" + color + "m", "\x1b[0m", ok
+		}
+	case "mono":
+		mark = func(k cell) (string, string, bool) {
+			b, ok := monoBrackets[k]
+			return b[0], b[1], ok
+		}
+	default:
+		return checkRenderStyle(style)
+	}
+
+	bw := bufio.NewWriter(w)
+	for row, kinds := range sc.cells() {
+		if !c.rows.contains(row) {
+			continue
+		}
+
+		var line strings.Builder
+		// last is the kind of the run of cells being printed
+		last := blankCell
+		for col, r := range sc.g.Row(row) {
+			if !c.cols.contains(col) {
+				continue
+			}
+
+			k := kinds[col]
+			if k != last || k == symbolCell || k == gearCell {
+				if _, after, ok := mark(last); ok {
+					line.WriteString(after)
+				}
+				if before, _, ok := mark(k); ok {
+					line.WriteString(before)
+				}
+				last = k
+			}
+			line.WriteRune(r)
+		}
+		if _, after, ok := mark(last); ok {
+			line.WriteString(after)
+		}
+
+		fmt.Fprintln(bw, line.String())
+	}
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("rendering schematic: %w", err)
+	}
+
+	return nil
+}
+
+// extent is a 1-based inclusive range of rows or columns, unbounded above when last is 0 and
+// covering everything when first is too.
+type extent struct {
+	first, last int
+}
+
+// contains reports whether the 0-based index i falls within the extent.
+func (e extent) contains(i int) bool {
+	return i+1 >= e.first && (e.last == 0 || i+1 <= e.last)
+}
+
+func (e extent) String() string {
+	switch {
+	case e.first == 0:
+		return ""
+	case e.first == e.last:
+		return strconv.Itoa(e.first)
+	case e.last == 0:
+		return fmt.Sprintf("%d-", e.first)
+	default:
+		return fmt.Sprintf("%d-%d", e.first, e.last)
+	}
+}
+
+// crop is the window of rows and columns of a schematic to render.
+type crop struct {
+	rows, cols extent
+}
+
+// String formats the crop in the same form accepted by Set.
+func (c *crop) String() string {
+	if c == nil || *c == (crop{}) {
+		return ""
+	}
+
+	return c.rows.String() + ":" + c.cols.String()
+}
+
+// Set parses a window written as rows:cols such as 10-20:30-70, where each side takes the same
+// n, n-m or n- forms as the number of parts of a rule and may be left empty to keep every row or
+// column. The columns may be left out altogether.
+func (c *crop) Set(in string) error {
+	rows, cols, _ := strings.Cut(in, ":")
+
+	var parsed crop
+	for _, side := range []struct {
+		name string
+		in   string
+		dst  *extent
+	}{
+		{"rows", rows, &parsed.rows},
+		{"columns", cols, &parsed.cols},
+	} {
+		if side.in == "" {
+			continue
+		}
+
+		first, last, err := parseArity(side.in)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: %w", side.name, side.in, err)
+		}
+		*side.dst = extent{first: first, last: last}
+	}
+
+	*c = parsed
+
+	return nil
+}
@@ -0,0 +1,328 @@
+package day03
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadSchematic(t *testing.T) {
+	testCases := []struct {
+		name              string
+		rules             string
+		expectedEvaluated int
+		expectedTotal     int
+	}{
+		{
+			name:              "puzzle gears",
+			rules:             "*=product:2",
+			expectedEvaluated: 2,
+			expectedTotal:     467835,
+		},
+		{
+			name:              "rule table",
+			rules:             "*=sum:2-,+=sum",
+			expectedEvaluated: 3,
+			expectedTotal:     467 + 35 + 755 + 598 + 592,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var rs rules
+			require.NoError(t, rs.Set(tc.rules))
+
+			sc, err := readSchematic(strings.NewReader(example), &standard, rs)
+			require.NoError(t, err)
+
+			assert.Equal(t, 10, sc.g.Rows())
+			assert.Len(t, sc.parts, 8)
+			require.Len(t, sc.evaluated, tc.expectedEvaluated)
+
+			var sum, total int
+			for _, p := range sc.parts {
+				sum += p.val
+			}
+			for _, e := range sc.evaluated {
+				total += e.value
+			}
+			assert.Equal(t, 4361, sum, "parts match part 1")
+			assert.Equal(t, tc.expectedTotal, total, "evaluated symbols match part 2")
+
+			rep, err := evaluate(strings.NewReader(example), &standard, rs)
+			require.NoError(t, err)
+			assert.Equal(t, rep.total, total)
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	testCases := []struct {
+		name    string
+		dialect dialect
+		// rules defaults to the puzzle's rule for the dialect's gear
+		rules       string
+		input       string
+		style       string
+		crop        string
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:    "mono",
+			dialect: standard,
+			input:   example,
+			style:   "mono",
+			expected: `[467]..(114)..
+...{*}......
+..[35]..[633].
+......<#>...
+[617]<*>......
+.....<+>.(58).
+..[592].....
+......[755].
+...<$>.{*}....
+.[664].[598]..
+`,
+		},
+		{
+			name:    "ansi",
+			dialect: standard,
+			input: `12*3.
+			....*`,
+			style: "ansi",
+			expected: "\x1b[32m12\x1b[0m\x1b[1;35m*\x1b[0m\x1b[32m3\x1b[0m.\n" +
+				"....\x1b[33m*\x1b[0m\n",
+		},
+		{
+			name:    "symbols evaluated by the rule table",
+			dialect: standard,
+			rules:   "*=sum:3,+=max",
+			input: `12*3.
+			.4..+`,
+			style: "mono",
+			expected: `[12]{*}[3].
+.[4]..{+}
+`,
+		},
+		{
+			name:    "gears the rule table leaves out",
+			dialect: standard,
+			rules:   "+=sum",
+			input:   `1*2+`,
+			style:   "mono",
+			expected: `[1]<*>[2]{+}
+`,
+		},
+		{
+			name:    "neighbouring gears are marked one by one",
+			dialect: standard,
+			input: `1**2
+			3..4`,
+			style: "mono",
+			expected: `[1]{*}{*}[2]
+[3]..[4]
+`,
+		},
+		{
+			name:    "cropped numbers are marked up to the edge",
+			dialect: standard,
+			input:   example,
+			style:   "mono",
+			crop:    "3-5:2-7",
+			expected: `.[35]..[6]
+.....<#>
+[17]<*>...
+`,
+		},
+		{
+			name:    "columns only",
+			dialect: standard,
+			input:   example,
+			style:   "mono",
+			crop:    ":9-",
+			expected: `..
+..
+[3].
+..
+..
+(8).
+..
+[5].
+..
+..
+`,
+		},
+		{
+			name:     "symbols that are not markers",
+			dialect:  dialect{blank: ".", markers: "*", gear: "*"},
+			input:    "5#.6*",
+			style:    "ansi",
+			expected: "\x1b[31m5\x1b[0m#.\x1b[32m6\x1b[0m\x1b[33m*\x1b[0m\n",
+		},
+		{
+			name:     "mono symbols that are not markers",
+			dialect:  dialect{blank: ".", markers: "*", gear: "*"},
+			input:    "5#.6**",
+			style:    "mono",
+			expected: "(5)#.[6]<*><*>\n",
+		},
+		{
+			name:        "unknown style",
+			dialect:     standard,
+			input:       example,
+			style:       "sepia",
+			expectedErr: fmt.Errorf(`unknown render style "sepia", expected one of ansi, mono`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var c crop
+			if tc.crop != "" {
+				require.NoError(t, c.Set(tc.crop))
+			}
+
+			rs := rules{gearRule(&tc.dialect)}
+			if tc.rules != "" {
+				require.NoError(t, rs.Set(tc.rules))
+			}
+
+			sc, err := readSchematic(strings.NewReader(tc.input), &tc.dialect, rs)
+			require.NoError(t, err)
+
+			var b strings.Builder
+			err = sc.render(&b, tc.style, c)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, b.String())
+		})
+	}
+}
+
+func TestCropSet(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       string
+		expected    crop
+		expectedErr error
+	}{
+		{
+			name:     "rows and columns",
+			input:    "10-20:30-70",
+			expected: crop{rows: extent{10, 20}, cols: extent{30, 70}},
+		},
+		{
+			name:     "single row from a column on",
+			input:    "4:7-",
+			expected: crop{rows: extent{4, 4}, cols: extent{7, 0}},
+		},
+		{
+			name:     "rows only",
+			input:    "2-3",
+			expected: crop{rows: extent{2, 3}},
+		},
+		{
+			name:     "columns only",
+			input:    ":5-6",
+			expected: crop{cols: extent{5, 6}},
+		},
+		{
+			name:        "zero row",
+			input:       "0-3",
+			expectedErr: fmt.Errorf(`invalid rows "0-3": "0" is not a positive number`),
+		},
+		{
+			name:        "backwards columns",
+			input:       "1:9-2",
+			expectedErr: fmt.Errorf(`invalid columns "9-2": "2" is not a number of at least 9`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var actual crop
+			err := actual.Set(tc.input)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+
+			var again crop
+			require.NoError(t, again.Set(actual.String()))
+			assert.Equal(t, actual, again, "String gives back what Set accepts")
+		})
+	}
+}
+
+func TestSolverRender(t *testing.T) {
+	input := `12*3.
+	.....`
+
+	testCases := []struct {
+		name        string
+		args        []string
+		expected    string
+		expectedErr error
+	}{
+		{
+			name: "not asked for",
+		},
+		{
+			name: "mono",
+			args: []string{"-render", "mono"},
+			expected: `[12]{*}[3].
+.....
+`,
+		},
+		{
+			name: "cropped after the report",
+			args: []string{"-render", "mono", "-crop", "1:3-", "-report"},
+			expected: `symbol       operator  parts  evaluated  skipped  total
+*            product   2      1          0        36
+grand total                                       36
+{*}[3].
+`,
+		},
+		{
+			name:        "unknown style",
+			args:        []string{"-render", "sepia"},
+			expectedErr: fmt.Errorf(`unknown render style "sepia", expected one of ansi, mono`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := configuredSolver(t, tc.args...)
+
+			part1, err := s.Part1(strings.NewReader(input))
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.EqualValues(t, 15, part1)
+
+			part2, err := s.Part2(strings.NewReader(input))
+			require.NoError(t, err)
+			assert.EqualValues(t, 36, part2)
+
+			var b strings.Builder
+			require.NoError(t, s.Report(&b))
+			assert.Equal(t, tc.expected, b.String())
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package day03
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+)
+
+// operators are the aggregations a rule can apply to the numbers of the parts next to a symbol.
+var operators = map[string]func(vals []int) int{
+	"product": func(vals []int) int {
+		out := 1
+		for _, v := range vals {
+			out *= v
+		}
+		return out
+	},
+	"sum": func(vals []int) int {
+		var out int
+		for _, v := range vals {
+			out += v
+		}
+		return out
+	},
+	"max": func(vals []int) int {
+		out := vals[0]
+		for _, v := range vals[1:] {
+			out = max(out, v)
+		}
+		return out
+	},
+	"count": func(vals []int) int {
+		return len(vals)
+	},
+}
+
+// operatorNames returns the names of every operator in alphabetical order.
+func operatorNames() []string {
+	names := make([]string, 0, len(operators))
+	for n := range operators {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// rule applies an operator to the parts next to every occurrence of a symbol, as long as there are
+// between min and max of them. A max of 0 leaves the number of parts unbounded.
+type rule struct {
+	symbol   string
+	op       string
+	min, max int
+}
+
+// gearParts is the number of parts a gear symbol must be next to for it to be a gear.
+const gearParts = 2
+
+// gearRule is the rule the puzzle gives the dialect's gear symbol.
+func gearRule(d *dialect) rule {
+	return rule{symbol: d.gear, op: "product", min: gearParts, max: gearParts}
+}
+
+// accepts reports whether a symbol next to n parts is evaluated by the rule.
+func (r rule) accepts(n int) bool {
+	return n >= r.min && (r.max == 0 || n <= r.max)
+}
+
+// arity formats the number of parts the rule accepts in the form accepted by rules.Set.
+func (r rule) arity() string {
+	switch {
+	case r.min == r.max:
+		return strconv.Itoa(r.min)
+	case r.max == 0:
+		return fmt.Sprintf("%d-", r.min)
+	default:
+		return fmt.Sprintf("%d-%d", r.min, r.max)
+	}
+}
+
+// rules is the table of rules used to evaluate a schematic, at most one per symbol.
+type rules []rule
+
+// String formats the rules in the same form accepted by Set.
+func (rs *rules) String() string {
+	if rs == nil {
+		return ""
+	}
+
+	out := make([]string, len(*rs))
+	for i, r := range *rs {
+		out[i] = fmt.Sprintf("%s=%s:%s", r.symbol, r.op, r.arity())
+	}
+
+	return strings.Join(out, ",")
+}
+
+// Set parses a comma separated list of symbol=operator rules such as *=product:2,+=sum. The
+// operator may be followed by the number of parts the symbol must be next to, either exactly n, a
+// range n-m or at least n-. Symbols next to any number of parts are evaluated otherwise.
+func (rs *rules) Set(in string) error {
+	var parsed rules
+	seen := map[string]bool{}
+	for _, entry := range strings.Split(in, ",") {
+		sym, def, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || utf8.RuneCountInString(sym) != 1 {
+			return fmt.Errorf("invalid rule %q, expected symbol=operator", entry)
+		}
+		if seen[sym] {
+			return fmt.Errorf("symbol %q given more than one rule", sym)
+		}
+		seen[sym] = true
+
+		op, arity, hasArity := strings.Cut(def, ":")
+		if _, ok := operators[op]; !ok {
+			return fmt.Errorf("unknown operator %q for %q, expected one of %s", op, sym, strings.Join(operatorNames(), ", "))
+		}
+
+		r := rule{symbol: sym, op: op, min: 1}
+		if hasArity {
+			var err error
+			if r.min, r.max, err = parseArity(arity); err != nil {
+				return fmt.Errorf("invalid number of parts for %q: %w", sym, err)
+			}
+		}
+
+		parsed = append(parsed, r)
+	}
+
+	*rs = parsed
+
+	return nil
+}
+
+// parseArity parses a number of parts written as n, n-m or n-.
+func parseArity(in string) (int, int, error) {
+	lo, hi, isRange := strings.Cut(in, "-")
+
+	least, err := strconv.Atoi(lo)
+	if err != nil || least < 1 {
+		return 0, 0, fmt.Errorf("%q is not a positive number", lo)
+	}
+
+	switch {
+	case !isRange:
+		return least, least, nil
+	case hi == "":
+		return least, 0, nil
+	}
+
+	most, err := strconv.Atoi(hi)
+	if err != nil || most < least {
+		return 0, 0, fmt.Errorf("%q is not a number of at least %d", hi, least)
+	}
+
+	return least, most, nil
+}
+
+// validate checks every rule can apply to schematics of the given dialect.
+func (rs rules) validate(d *dialect) error {
+	for _, r := range rs {
+		if c, _ := utf8.DecodeRuneInString(r.symbol); !d.isMarker(c) || strings.ContainsRune(d.ignored, c) {
+			return fmt.Errorf("rule for %q never applies, it is not a part marker", r.symbol)
+		}
+	}
+
+	return nil
+}
+
+// ruleResult totals the evaluations of a single rule.
+type ruleResult struct {
+	rule
+	// evaluated and skipped count the symbols next to a number of parts the rule accepts and
+	// those next to any other number.
+	evaluated, skipped int
+	total              int
+}
+
+// report holds the result of every rule in the order of the rule table, along with their sum.
+type report struct {
+	results []ruleResult
+	total   int
+}
+
+// evaluation is a symbol a rule was applied to, along with the value the rule gave it.
+type evaluation struct {
+	*cluster
+	value int
+}
+
+// evaluate streams the schematic from r, applying the rule of every symbol with one to the parts
+// next to it.
+func evaluate(r io.Reader, d *dialect, rs rules) (*report, error) {
+	return evaluateEach(r, d, rs, func(evaluation) {})
+}
+
+// evaluateEach evaluates the schematic like evaluate, also handing every symbol a rule was applied
+// to over to fn in reading order.
+func evaluateEach(r io.Reader, d *dialect, rs rules, fn func(evaluation)) (*report, error) {
+	rep := &report{results: make([]ruleResult, len(rs))}
+	bySymbol := map[string]*ruleResult{}
+	for i, r := range rs {
+		rep.results[i].rule = r
+		bySymbol[r.symbol] = &rep.results[i]
+	}
+
+	hasRule := func(kind string) bool { return bySymbol[kind] != nil }
+	err := scanSymbols(r, d, hasRule, func(c *cluster) {
+		res := bySymbol[c.kind]
+		if !res.accepts(len(c.parts)) {
+			res.skipped++
+			return
+		}
+
+		vals := make([]int, len(c.parts))
+		for i, p := range c.parts {
+			vals[i] = p.val
+		}
+
+		value := operators[res.op](vals)
+		res.evaluated++
+		res.total += value
+		fn(evaluation{cluster: c, value: value})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, res := range rep.results {
+		rep.total += res.total
+	}
+
+	return rep, nil
+}
+
+// write prints the report as a table, one row per rule followed by the grand total.
+func (rep *report) write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "symbol\toperator\tparts\tevaluated\tskipped\ttotal")
+	for _, res := range rep.results {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%d\t%d\n", res.symbol, res.op, res.arity(), res.evaluated, res.skipped, res.total)
+	}
+	fmt.Fprintf(tw, "grand total\t\t\t\t\t%d\n", rep.total)
+
+	return tw.Flush()
+}
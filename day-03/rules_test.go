@@ -0,0 +1,247 @@
+package day03
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mxygem/advent-of-code-2023/aoc"
+)
+
+const example = `467..114..
+...*......
+..35..633.
+......#...
+617*......
+.....+.58.
+..592.....
+......755.
+...$.*....
+.664.598..`
+
+func TestRulesSet(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       string
+		expected    rules
+		expectedErr error
+	}{
+		{
+			name:  "every form of arity",
+			input: "*=product:2, +=sum, #=max:2-, $=count:1-3",
+			expected: rules{
+				{symbol: "*", op: "product", min: 2, max: 2},
+				{symbol: "+", op: "sum", min: 1},
+				{symbol: "#", op: "max", min: 2},
+				{symbol: "$", op: "count", min: 1, max: 3},
+			},
+		},
+		{
+			name:     "multibyte symbol",
+			input:    "§=sum",
+			expected: rules{{symbol: "§", op: "sum", min: 1}},
+		},
+		{
+			name:        "missing operator",
+			input:       "*",
+			expectedErr: fmt.Errorf(`invalid rule "*", expected symbol=operator`),
+		},
+		{
+			name:        "several symbols",
+			input:       "**=sum",
+			expectedErr: fmt.Errorf(`invalid rule "**=sum", expected symbol=operator`),
+		},
+		{
+			name:        "repeated symbol",
+			input:       "*=sum,*=max",
+			expectedErr: fmt.Errorf(`symbol "*" given more than one rule`),
+		},
+		{
+			name:        "unknown operator",
+			input:       "*=mean",
+			expectedErr: fmt.Errorf(`unknown operator "mean" for "*", expected one of count, max, product, sum`),
+		},
+		{
+			name:        "no parts",
+			input:       "*=sum:0",
+			expectedErr: fmt.Errorf(`invalid number of parts for "*": "0" is not a positive number`),
+		},
+		{
+			name:        "backwards range",
+			input:       "*=sum:3-2",
+			expectedErr: fmt.Errorf(`invalid number of parts for "*": "2" is not a number of at least 3`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var actual rules
+			err := actual.Set(tc.input)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+
+			var again rules
+			require.NoError(t, again.Set(actual.String()))
+			assert.Equal(t, actual, again, "String gives back what Set accepts")
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rules    string
+		input    string
+		expected *report
+	}{
+		{
+			name:  "puzzle gears",
+			rules: "*=product:2",
+			input: example,
+			expected: &report{
+				results: []ruleResult{
+					{rule: rule{symbol: "*", op: "product", min: 2, max: 2}, evaluated: 2, skipped: 1, total: 467835},
+				},
+				total: 467835,
+			},
+		},
+		{
+			name:  "every operator",
+			rules: "*=product:2,+=sum,#=max,$=count",
+			input: example,
+			expected: &report{
+				results: []ruleResult{
+					{rule: rule{symbol: "*", op: "product", min: 2, max: 2}, evaluated: 2, skipped: 1, total: 467835},
+					{rule: rule{symbol: "+", op: "sum", min: 1}, evaluated: 1, total: 592},
+					{rule: rule{symbol: "#", op: "max", min: 1}, evaluated: 1, total: 633},
+					{rule: rule{symbol: "$", op: "count", min: 1}, evaluated: 1, total: 1},
+				},
+				total: 467835 + 592 + 633 + 1,
+			},
+		},
+		{
+			name:  "operators over several parts",
+			rules: "+=sum,#=max,$=count:3-",
+			input: `1.2.3
+			.+.#.
+			4.5.6
+			..$..
+			.7.8.`,
+			expected: &report{
+				results: []ruleResult{
+					{rule: rule{symbol: "+", op: "sum", min: 1}, evaluated: 1, total: 1 + 2 + 4 + 5},
+					{rule: rule{symbol: "#", op: "max", min: 1}, evaluated: 1, total: 6},
+					{rule: rule{symbol: "$", op: "count", min: 3}, evaluated: 1, total: 3},
+				},
+				total: 12 + 6 + 3,
+			},
+		},
+		{
+			name:  "symbols without a rule are left alone",
+			rules: "&=sum",
+			input: example,
+			expected: &report{
+				results: []ruleResult{{rule: rule{symbol: "&", op: "sum", min: 1}}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var rs rules
+			require.NoError(t, rs.Set(tc.rules))
+
+			actual, err := evaluate(strings.NewReader(tc.input), &standard, rs)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestReportWrite(t *testing.T) {
+	rep := &report{
+		results: []ruleResult{
+			{rule: rule{symbol: "*", op: "product", min: 2, max: 2}, evaluated: 2, skipped: 1, total: 467835},
+			{rule: rule{symbol: "$", op: "count", min: 1, max: 3}, evaluated: 1, total: 1},
+		},
+		total: 467836,
+	}
+
+	var b strings.Builder
+	require.NoError(t, rep.write(&b))
+
+	assert.Equal(t, `symbol       operator  parts  evaluated  skipped  total
+*            product   2      2          1        467835
+$            count     1-3    1          0        1
+grand total                                       467836
+`, b.String())
+}
+
+func TestSolverRules(t *testing.T) {
+	testCases := []struct {
+		name           string
+		args           []string
+		expected       aoc.Answer
+		expectedReport string
+		expectedErr    error
+	}{
+		{
+			name:     "puzzle gears by default",
+			expected: 467835,
+		},
+		{
+			name:     "default follows the gear symbol",
+			args:     []string{"-gear", "$"},
+			expected: 0,
+		},
+		{
+			name:     "rule table",
+			args:     []string{"-rules", "*=product:2,+=sum"},
+			expected: 467835 + 592,
+		},
+		{
+			name:     "report",
+			args:     []string{"-rules", "+=sum", "-report"},
+			expected: 592,
+			expectedReport: `symbol       operator  parts  evaluated  skipped  total
++            sum       1-     1          0        592
+grand total                                       592
+`,
+		},
+		{
+			name:        "rule for a symbol that is not a part marker",
+			args:        []string{"-markers", "*", "-rules", "*=product:2,+=sum"},
+			expectedErr: fmt.Errorf(`rule for "+" never applies, it is not a part marker`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := configuredSolver(t, tc.args...)
+
+			actual, err := s.Part2(strings.NewReader(example))
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+
+			var report strings.Builder
+			require.NoError(t, s.Report(&report))
+			assert.Equal(t, tc.expectedReport, report.String())
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package day03
+
+import (
+	"io"
+	"strings"
+
+	"github.com/mxygem/advent-of-code-2023/aoc"
+	"github.com/mxygem/advent-of-code-2023/grid"
+)
+
+// schematic is a whole schematic held in memory along with the parts the solver finds in it and the
+// symbols its rules evaluate, for when it is to be drawn rather than only solved.
+type schematic struct {
+	d *dialect
+	// g holds the rows of the schematic as the solver lays them out, ignored runes removed.
+	g         *grid.Grid
+	parts     []*part
+	evaluated []evaluation
+}
+
+// readSchematic reads the whole schematic from r, finding its parts and evaluating its symbols
+// against rs the same way the solver does.
+func readSchematic(r io.Reader, d *dialect, rs rules) (*schematic, error) {
+	var rows []string
+	err := aoc.ScanLines(r, func(_ int, text string) {
+		rows = append(rows, strings.TrimSpace(d.strip(text)))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sc := &schematic{d: d, g: grid.New(rows)}
+	laidOut := strings.Join(rows, "\n")
+
+	err = scanParts(strings.NewReader(laidOut), d, func(p *part) {
+		sc.parts = append(sc.parts, p)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = evaluateEach(strings.NewReader(laidOut), d, rs, func(e evaluation) {
+		sc.evaluated = append(sc.evaluated, e)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sc, nil
+}
+
+// cell is what a cell of a schematic holds, as far as drawing it is concerned.
+type cell int
+
+const (
+	// blankCell is an empty cell or a symbol that is not a part marker.
+	blankCell cell = iota
+	partCell
+	// numberCell is a digit of a number next to no part marker.
+	numberCell
+	symbolCell
+	// gearCell is a symbol evaluated by a rule, which under the puzzle's own rule is a gear.
+	gearCell
+)
+
+// cells classifies every cell of the schematic, one slice per row.
+func (sc *schematic) cells() [][]cell {
+	out := make([][]cell, sc.g.Rows())
+	for row := range out {
+		out[row] = make([]cell, sc.g.Cols(row))
+		sc.g.EachInRow(row, func(p grid.Point, c rune) bool {
+			switch {
+			case isDigit(c):
+				out[row][p.Col] = numberCell
+			case sc.d.isMarker(c):
+				out[row][p.Col] = symbolCell
+			}
+			return true
+		})
+	}
+
+	for _, p := range sc.parts {
+		for col := p.Start; col <= p.End; col++ {
+			out[p.Row][col] = partCell
+		}
+	}
+	for _, e := range sc.evaluated {
+		out[e.Row][e.Col] = gearCell
+	}
+
+	return out
+}
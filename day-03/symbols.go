@@ -0,0 +1,95 @@
+package day03
+
+import (
+	"io"
+	"math"
+	"sort"
+
+	"github.com/mxygem/advent-of-code-2023/grid"
+)
+
+// cluster is a symbol along with every part next to it.
+type cluster struct {
+	symbol
+	parts []*part
+}
+
+// symbolIndex groups the parts found next to the symbols it keeps by the position of the symbol, so
+// symbols can be evaluated without comparing parts with each other. resolved is the row above which
+// every symbol has already been removed.
+type symbolIndex struct {
+	keep     func(kind string) bool
+	byPos    map[grid.Point]*cluster
+	resolved int
+}
+
+// newSymbolIndex returns an index of the symbols for which keep returns true.
+func newSymbolIndex(keep func(kind string) bool) *symbolIndex {
+	return &symbolIndex{keep: keep, byPos: map[grid.Point]*cluster{}}
+}
+
+// add records p against every kept symbol next to it.
+func (x *symbolIndex) add(p *part) {
+	for _, sym := range p.symbols {
+		if !x.keep(sym.kind) {
+			continue
+		}
+
+		c, ok := x.byPos[sym.Point]
+		if !ok {
+			c = &cluster{symbol: *sym}
+			x.byPos[sym.Point] = c
+		}
+		c.parts = append(c.parts, p)
+	}
+}
+
+// resolve removes every symbol above the given row from the index, returning them in reading order.
+// Rows are only ever resolved once, so nothing is returned unless before is past the last row
+// resolved.
+func (x *symbolIndex) resolve(before int) []*cluster {
+	if before <= x.resolved {
+		return nil
+	}
+	x.resolved = before
+
+	var done []*cluster
+	for pos, c := range x.byPos {
+		if pos.Row < before {
+			done = append(done, c)
+			delete(x.byPos, pos)
+		}
+	}
+	sort.Slice(done, func(i, j int) bool {
+		if done[i].Row != done[j].Row {
+			return done[i].Row < done[j].Row
+		}
+		return done[i].Col < done[j].Col
+	})
+
+	return done
+}
+
+// scanSymbols streams the schematic from r, handing every symbol for which keep returns true to fn
+// along with its parts as soon as the rows that could add to it have been read. Symbols without any
+// parts next to them are never handed over.
+func scanSymbols(r io.Reader, d *dialect, keep func(kind string) bool, fn func(*cluster)) error {
+	x := newSymbolIndex(keep)
+	resolve := func(before int) {
+		for _, c := range x.resolve(before) {
+			fn(c)
+		}
+	}
+
+	err := scanParts(r, d, func(p *part) {
+		// parts arrive row by row, so a symbol more than a row above this part has all of its own
+		resolve(p.Row - 1)
+		x.add(p)
+	})
+	if err != nil {
+		return err
+	}
+	resolve(math.MaxInt)
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package day03
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mxygem/advent-of-code-2023/grid"
+)
+
+func TestSymbolIndex(t *testing.T) {
+	star := func(row, col int) *symbol {
+		return &symbol{kind: "*", Point: grid.Point{Row: row, Col: col}}
+	}
+
+	testCases := []struct {
+		name     string
+		input    []*part
+		expected []*cluster
+	}{
+		{
+			name: "symbols not kept",
+			input: []*part{
+				{val: 1, symbols: []*symbol{{kind: "@", Point: grid.Point{Row: 1, Col: 3}}}},
+				{val: 2, symbols: []*symbol{{kind: "&", Point: grid.Point{Row: 1, Col: 3}}}},
+			},
+		},
+		{
+			name: "parts grouped by symbol in reading order",
+			input: []*part{
+				{val: 2, symbols: []*symbol{star(2, 5)}},
+				{val: 5, symbols: []*symbol{star(1, 3)}},
+				{val: 1, symbols: []*symbol{star(0, 2)}},
+				{val: 4, symbols: []*symbol{star(1, 3)}},
+				{val: 3, symbols: []*symbol{star(0, 2)}},
+			},
+			expected: []*cluster{
+				{symbol: *star(0, 2), parts: []*part{{val: 1, symbols: []*symbol{star(0, 2)}}, {val: 3, symbols: []*symbol{star(0, 2)}}}},
+				{symbol: *star(1, 3), parts: []*part{{val: 5, symbols: []*symbol{star(1, 3)}}, {val: 4, symbols: []*symbol{star(1, 3)}}}},
+				{symbol: *star(2, 5), parts: []*part{{val: 2, symbols: []*symbol{star(2, 5)}}}},
+			},
+		},
+		{
+			name: "part next to several symbols",
+			input: []*part{
+				{val: 7, symbols: []*symbol{star(0, 0), {kind: "#", Point: grid.Point{Row: 0, Col: 1}}, star(0, 2)}},
+			},
+			expected: []*cluster{
+				{symbol: *star(0, 0), parts: []*part{{val: 7, symbols: []*symbol{star(0, 0), {kind: "#", Point: grid.Point{Row: 0, Col: 1}}, star(0, 2)}}}},
+				{symbol: *star(0, 2), parts: []*part{{val: 7, symbols: []*symbol{star(0, 0), {kind: "#", Point: grid.Point{Row: 0, Col: 1}}, star(0, 2)}}}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			x := newSymbolIndex(func(kind string) bool { return kind == "*" })
+			for _, p := range tc.input {
+				x.add(p)
+			}
+
+			assert.Equal(t, tc.expected, x.resolve(3))
+			assert.Empty(t, x.byPos)
+		})
+	}
+}
+
+func TestSymbolIndexResolvesRowsAbove(t *testing.T) {
+	x := newSymbolIndex(func(kind string) bool { return kind == "*" })
+	x.add(&part{val: 2, symbols: []*symbol{{kind: "*", Point: grid.Point{Row: 0, Col: 1}}}})
+	x.add(&part{val: 3, symbols: []*symbol{{kind: "*", Point: grid.Point{Row: 0, Col: 1}}}})
+	x.add(&part{val: 4, symbols: []*symbol{{kind: "*", Point: grid.Point{Row: 1, Col: 1}}}})
+
+	assert.Empty(t, x.resolve(0))
+	assert.Len(t, x.resolve(1), 1)
+	assert.Len(t, x.byPos, 1, "the symbol on row 1 can still gain parts")
+
+	x.add(&part{val: 5, symbols: []*symbol{{kind: "*", Point: grid.Point{Row: 1, Col: 1}}}})
+	assert.Empty(t, x.resolve(1), "row 0 is already resolved")
+	assert.Len(t, x.resolve(2), 1)
+}
@@ -0,0 +1,198 @@
+// Package grid provides a two dimensional map of characters along with the neighbour and bounds
+// handling shared by puzzles laid out on a grid.
+package grid
+
+// Point is a cell location within a grid.
+type Point struct {
+	Row, Col int
+}
+
+// Add returns the point offset from p by d.
+func (p Point) Add(d Point) Point {
+	return Point{Row: p.Row + d.Row, Col: p.Col + d.Col}
+}
+
+var (
+	// Directions4 are the offsets to the orthogonal neighbours of a point: up, left, right, down.
+	Directions4 = []Point{{-1, 0}, {0, -1}, {0, 1}, {1, 0}}
+	// Directions8 are the offsets to every neighbour of a point including diagonals, in reading
+	// order.
+	Directions8 = []Point{{-1, -1}, {-1, 0}, {-1, 1}, {0, -1}, {0, 1}, {1, -1}, {1, 0}, {1, 1}}
+)
+
+// Span is a horizontal run of cells within a single row, from Start to End inclusive.
+type Span struct {
+	Row        int
+	Start, End int
+}
+
+// Contains reports whether p lies within the span.
+func (s Span) Contains(p Point) bool {
+	return p.Row == s.Row && p.Col >= s.Start && p.Col <= s.End
+}
+
+// Grid is a two dimensional map of runes. Rows are not required to share the same length.
+type Grid struct {
+	cells [][]rune
+}
+
+// New builds a grid with one row per line.
+func New(lines []string) *Grid {
+	cells := make([][]rune, len(lines))
+	for i, l := range lines {
+		cells[i] = []rune(l)
+	}
+
+	return &Grid{cells: cells}
+}
+
+// Rows returns the number of rows in the grid.
+func (g *Grid) Rows() int {
+	return len(g.cells)
+}
+
+// Cols returns the number of cells in the given row, or 0 if the row does not exist.
+func (g *Grid) Cols(row int) int {
+	if row < 0 || row >= len(g.cells) {
+		return 0
+	}
+
+	return len(g.cells[row])
+}
+
+// Row returns the cells of the given row, or nil if the row does not exist.
+func (g *Grid) Row(row int) []rune {
+	if row < 0 || row >= len(g.cells) {
+		return nil
+	}
+
+	return g.cells[row]
+}
+
+// InBounds reports whether p refers to a cell of the grid.
+func (g *Grid) InBounds(p Point) bool {
+	return p.Row >= 0 && p.Row < len(g.cells) && p.Col >= 0 && p.Col < len(g.cells[p.Row])
+}
+
+// At returns the rune at p and whether p is within the grid.
+func (g *Grid) At(p Point) (rune, bool) {
+	if !g.InBounds(p) {
+		return 0, false
+	}
+
+	return g.cells[p.Row][p.Col], true
+}
+
+// Neighbors4 returns the orthogonal neighbours of p that lie within the grid.
+func (g *Grid) Neighbors4(p Point) []Point {
+	return g.neighbors(p, Directions4)
+}
+
+// Neighbors8 returns every neighbour of p, diagonals included, that lies within the grid.
+func (g *Grid) Neighbors8(p Point) []Point {
+	return g.neighbors(p, Directions8)
+}
+
+func (g *Grid) neighbors(p Point, dirs []Point) []Point {
+	var ns []Point
+	for _, d := range dirs {
+		n := p.Add(d)
+		if g.InBounds(n) {
+			ns = append(ns, n)
+		}
+	}
+
+	return ns
+}
+
+// Each calls fn for every cell in reading order until fn returns false.
+func (g *Grid) Each(fn func(Point, rune) bool) {
+	for r := range g.cells {
+		if !g.EachInRow(r, fn) {
+			return
+		}
+	}
+}
+
+// EachInRow calls fn for every cell of the given row from left to right until fn returns false. It
+// reports whether the whole row was visited.
+func (g *Grid) EachInRow(row int, fn func(Point, rune) bool) bool {
+	for c, v := range g.Row(row) {
+		if !fn(Point{Row: row, Col: c}, v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// EachInCol calls fn for every cell of the given column from top to bottom until fn returns false,
+// skipping rows too short to reach the column. It reports whether the whole column was visited.
+func (g *Grid) EachInCol(col int, fn func(Point, rune) bool) bool {
+	for r := range g.cells {
+		p := Point{Row: r, Col: col}
+		v, ok := g.At(p)
+		if !ok {
+			continue
+		}
+
+		if !fn(p, v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Spans scans the given row for maximal runs of cells satisfying match, returned left to right.
+func (g *Grid) Spans(row int, match func(rune) bool) []Span {
+	var spans []Span
+
+	start := -1
+	for c, v := range g.Row(row) {
+		if match(v) {
+			if start < 0 {
+				start = c
+			}
+			continue
+		}
+
+		if start >= 0 {
+			spans = append(spans, Span{Row: row, Start: start, End: c - 1})
+			start = -1
+		}
+	}
+
+	if start >= 0 {
+		spans = append(spans, Span{Row: row, Start: start, End: g.Cols(row) - 1})
+	}
+
+	return spans
+}
+
+// Around returns every cell within the grid touching s, diagonals included, in reading order: the
+// row above, the cells either side of the span and then the row below.
+func (g *Grid) Around(s Span) []Point {
+	var ps []Point
+
+	for c := s.Start - 1; c <= s.End+1; c++ {
+		ps = g.appendInBounds(ps, Point{Row: s.Row - 1, Col: c})
+	}
+
+	ps = g.appendInBounds(ps, Point{Row: s.Row, Col: s.Start - 1})
+	ps = g.appendInBounds(ps, Point{Row: s.Row, Col: s.End + 1})
+
+	for c := s.Start - 1; c <= s.End+1; c++ {
+		ps = g.appendInBounds(ps, Point{Row: s.Row + 1, Col: c})
+	}
+
+	return ps
+}
+
+func (g *Grid) appendInBounds(ps []Point, p Point) []Point {
+	if !g.InBounds(p) {
+		return ps
+	}
+
+	return append(ps, p)
+}
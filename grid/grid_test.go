@@ -0,0 +1,227 @@
+package grid
+
+import (
+	"testing"
+	"unicode"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInBounds(t *testing.T) {
+	g := New([]string{
+		"abc",
+		"d",
+	})
+
+	testCases := []struct {
+		name     string
+		point    Point
+		expected bool
+	}{
+		{
+			name:     "origin",
+			point:    Point{0, 0},
+			expected: true,
+		},
+		{
+			name:     "last cell of first row",
+			point:    Point{0, 2},
+			expected: true,
+		},
+		{
+			name:     "beyond short row",
+			point:    Point{1, 1},
+			expected: false,
+		},
+		{
+			name:     "negative row",
+			point:    Point{-1, 0},
+			expected: false,
+		},
+		{
+			name:     "negative col",
+			point:    Point{0, -1},
+			expected: false,
+		},
+		{
+			name:     "below last row",
+			point:    Point{2, 0},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, g.InBounds(tc.point))
+		})
+	}
+}
+
+func TestAt(t *testing.T) {
+	g := New([]string{"a§c"})
+
+	r, ok := g.At(Point{0, 1})
+	assert.True(t, ok)
+	assert.Equal(t, '§', r)
+
+	r, ok = g.At(Point{0, 3})
+	assert.False(t, ok)
+	assert.Equal(t, rune(0), r)
+}
+
+func TestNeighbors(t *testing.T) {
+	g := New([]string{
+		"abc",
+		"def",
+		"ghi",
+	})
+
+	testCases := []struct {
+		name      string
+		point     Point
+		expected4 []Point
+		expected8 []Point
+	}{
+		{
+			name:      "center",
+			point:     Point{1, 1},
+			expected4: []Point{{0, 1}, {1, 0}, {1, 2}, {2, 1}},
+			expected8: []Point{{0, 0}, {0, 1}, {0, 2}, {1, 0}, {1, 2}, {2, 0}, {2, 1}, {2, 2}},
+		},
+		{
+			name:      "top left corner",
+			point:     Point{0, 0},
+			expected4: []Point{{0, 1}, {1, 0}},
+			expected8: []Point{{0, 1}, {1, 0}, {1, 1}},
+		},
+		{
+			name:      "bottom edge",
+			point:     Point{2, 1},
+			expected4: []Point{{1, 1}, {2, 0}, {2, 2}},
+			expected8: []Point{{1, 0}, {1, 1}, {1, 2}, {2, 0}, {2, 2}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected4, g.Neighbors4(tc.point))
+			assert.Equal(t, tc.expected8, g.Neighbors8(tc.point))
+		})
+	}
+}
+
+func TestIterators(t *testing.T) {
+	g := New([]string{
+		"ab",
+		"c",
+		"de",
+	})
+
+	var all string
+	g.Each(func(_ Point, r rune) bool {
+		all += string(r)
+		return true
+	})
+	assert.Equal(t, "abcde", all)
+
+	var row string
+	assert.True(t, g.EachInRow(2, func(_ Point, r rune) bool {
+		row += string(r)
+		return true
+	}))
+	assert.Equal(t, "de", row)
+
+	var col []Point
+	assert.True(t, g.EachInCol(1, func(p Point, _ rune) bool {
+		col = append(col, p)
+		return true
+	}))
+	assert.Equal(t, []Point{{0, 1}, {2, 1}}, col)
+
+	var visited int
+	g.Each(func(p Point, _ rune) bool {
+		visited++
+		return p != Point{0, 1}
+	})
+	assert.Equal(t, 2, visited, "stops once fn returns false")
+}
+
+func TestSpans(t *testing.T) {
+	testCases := []struct {
+		name     string
+		line     string
+		expected []Span
+	}{
+		{
+			name: "no number found",
+			line: `....#.....`,
+		},
+		{
+			name:     "number at start",
+			line:     `123.#.....`,
+			expected: []Span{{Start: 0, End: 2}},
+		},
+		{
+			name:     "number at end",
+			line:     `*#..#..*89`,
+			expected: []Span{{Start: 8, End: 9}},
+		},
+		{
+			name:     "whole line is a single number",
+			line:     `9876543210`,
+			expected: []Span{{Start: 0, End: 9}},
+		},
+		{
+			name:     "several numbers",
+			line:     `1..23.456`,
+			expected: []Span{{Start: 0, End: 0}, {Start: 3, End: 4}, {Start: 6, End: 8}},
+		},
+		{
+			name:     "columns count runes not bytes",
+			line:     `§→12`,
+			expected: []Span{{Start: 2, End: 3}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, New([]string{tc.line}).Spans(0, unicode.IsDigit))
+		})
+	}
+}
+
+func TestAround(t *testing.T) {
+	g := New([]string{
+		"abcde",
+		"fghij",
+		"klmno",
+	})
+
+	testCases := []struct {
+		name     string
+		span     Span
+		expected []Point
+	}{
+		{
+			name:     "middle",
+			span:     Span{Row: 1, Start: 2, End: 3},
+			expected: []Point{{0, 1}, {0, 2}, {0, 3}, {0, 4}, {1, 1}, {1, 4}, {2, 1}, {2, 2}, {2, 3}, {2, 4}},
+		},
+		{
+			name:     "top left",
+			span:     Span{Row: 0, Start: 0, End: 1},
+			expected: []Point{{0, 2}, {1, 0}, {1, 1}, {1, 2}},
+		},
+		{
+			name:     "bottom right",
+			span:     Span{Row: 2, Start: 4, End: 4},
+			expected: []Point{{1, 3}, {1, 4}, {2, 3}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, g.Around(tc.span))
+		})
+	}
+}